@@ -0,0 +1,125 @@
+package alien
+
+import "context"
+
+// AsyncSignal holds the latest result of an asynchronous loader, see
+// AsyncComputed.
+type AsyncSignal[T any] struct {
+	rs         *ReactiveSystem
+	value      *WriteableSignal[T]
+	err        *WriteableSignal[error]
+	loading    *WriteableSignal[bool]
+	generation uint64
+	stop       ErrFn
+}
+
+// Value returns the result of the last successful load, tracked like any
+// other signal read.
+func (a *AsyncSignal[T]) Value() T {
+	return a.value.Value()
+}
+
+// Err returns the error of the last finished load, or nil if it succeeded.
+func (a *AsyncSignal[T]) Err() error {
+	return a.err.Value()
+}
+
+// Loading reports whether a load is in flight.
+func (a *AsyncSignal[T]) Loading() bool {
+	return a.loading.Value()
+}
+
+// Stop cancels any in-flight load, stops tracking the source and clears
+// Loading, as the cancelled load will never finish.
+func (a *AsyncSignal[T]) Stop() error {
+	var err error
+	a.rs.Batch(func() {
+		err = a.stop()
+		a.loading.SetValue(false)
+	})
+	return err
+}
+
+// AsyncComputed runs loader in a goroutine every time the value returned by
+// source changes.
+//
+// source runs synchronously and tracks dependencies like a Computed getter.
+// Each load gets a context derived from the system's root context, see
+// WithContext, that is cancelled as soon as source re-runs, the AsyncSignal is
+// stopped or an enclosing EffectScope is disposed, and only the latest load
+// may update Value, Err and Loading.
+//
+// Loads finish on their own goroutines, so rs must be created WithConcurrency.
+func AsyncComputed[K any, T comparable](
+	rs *ReactiveSystem,
+	source func() K,
+	loader func(ctx context.Context, key K) (T, error),
+) *AsyncSignal[T] {
+	return AsyncComputedFunc(rs, source, loader, Equal[T])
+}
+
+// AsyncComputedFunc is AsyncComputed for any type, using equals to decide
+// whether a finished load changed the value.
+func AsyncComputedFunc[K any, T any](
+	rs *ReactiveSystem,
+	source func() K,
+	loader func(ctx context.Context, key K) (T, error),
+	equals func(a, b T) bool,
+) *AsyncSignal[T] {
+	if rs.mu == nil {
+		panic("AsyncComputed requires a ReactiveSystem created WithConcurrency")
+	}
+
+	var zero T
+	a := &AsyncSignal[T]{
+		rs:    rs,
+		value: SignalFunc(rs, zero, equals),
+		err: SignalFunc(rs, nil, func(a, b error) bool {
+			return a == nil && b == nil
+		}),
+		loading: Signal(rs, false),
+	}
+
+	a.stop = Effect(rs, func() error {
+		key := source()
+
+		ctx, cancel := context.WithCancel(rs.ctx)
+		OnCleanup(rs, cancel)
+		a.generation++
+		generation := a.generation
+		a.loading.SetValue(true)
+
+		go a.load(ctx, generation, func(ctx context.Context) (T, error) {
+			return loader(ctx, key)
+		})
+		return nil
+	})
+
+	return a
+}
+
+func (a *AsyncSignal[T]) load(ctx context.Context, generation uint64, loader func(ctx context.Context) (T, error)) {
+	var (
+		v   T
+		err error
+	)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = newPanicError(r)
+			}
+		}()
+		v, err = loader(ctx)
+	}()
+
+	a.rs.Batch(func() {
+		if generation != a.generation || ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			a.value.SetValue(v)
+		}
+		a.err.SetValue(err)
+		a.loading.SetValue(false)
+	})
+}
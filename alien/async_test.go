@@ -0,0 +1,120 @@
+package alien_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncComputedLatestLoadWins(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency())
+
+	id := alien.Signal(rs, 1)
+	release := map[int]chan struct{}{
+		1: make(chan struct{}),
+		2: make(chan struct{}),
+	}
+	cancelled := make(chan int, 2)
+
+	user := alien.AsyncComputed(rs, id.Value, func(ctx context.Context, id int) (string, error) {
+		select {
+		case <-release[id]:
+			return fmt.Sprintf("user%d", id), nil
+		case <-ctx.Done():
+			cancelled <- id
+			return "", ctx.Err()
+		}
+	})
+	defer user.Stop()
+
+	assert.True(t, user.Loading())
+
+	id.SetValue(2)
+	select {
+	case got := <-cancelled:
+		assert.Equal(t, 1, got)
+	case <-time.After(time.Second):
+		require.FailNow(t, "first load was not cancelled")
+	}
+
+	close(release[2])
+	require.Eventually(t, func() bool {
+		return !user.Loading()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "user2", user.Value())
+	assert.NoError(t, user.Err())
+}
+
+func TestAsyncComputedReportsErrors(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency())
+
+	errNotFound := errors.New("not found")
+	id := alien.Signal(rs, 0)
+	user := alien.AsyncComputed(rs, id.Value, func(ctx context.Context, id int) (int, error) {
+		if id < 0 {
+			return 0, errNotFound
+		}
+		return id * 10, nil
+	})
+
+	states := make(chan bool, 16)
+	stop := alien.Effect(rs, func() error {
+		states <- user.Loading()
+		return nil
+	})
+	defer stop()
+
+	require.Eventually(t, func() bool {
+		return !user.Loading()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, user.Value())
+
+	id.SetValue(-1)
+	require.Eventually(t, func() bool {
+		return user.Err() != nil
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, user.Err(), errNotFound)
+	assert.False(t, user.Loading())
+
+	id.SetValue(4)
+	require.Eventually(t, func() bool {
+		return user.Value() == 40
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, user.Err())
+	assert.True(t, <-states)
+}
+
+func TestAsyncComputedCancelledOnStop(t *testing.T) {
+	rs := alien.CreateReactiveSystem(nil, alien.WithConcurrency())
+
+	cancelled := make(chan struct{})
+	user := alien.AsyncComputed(rs, func() int { return 1 }, func(ctx context.Context, id int) (int, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return 0, ctx.Err()
+	})
+	loading := []bool{}
+	alien.Effect(rs, func() error {
+		loading = append(loading, user.Loading())
+		return nil
+	})
+
+	user.Stop()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		require.FailNow(t, "load was not cancelled")
+	}
+	assert.False(t, user.Loading())
+	assert.Equal(t, []bool{true, false}, loading)
+}
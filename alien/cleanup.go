@@ -0,0 +1,59 @@
+package alien
+
+type cleanupOwner interface {
+	cleanupList() *[]func()
+}
+
+// OnCleanup registers fn to run before the currently running effect, effect
+// scope or computed runs again, and when it is stopped or disposed.
+//
+// Effects clean up before every re-run, when their stop ErrFn is called and
+// when an enclosing EffectScope is disposed. Computeds clean up before every
+// recomputation and when they lose their last subscriber. Cleanups run in LIFO
+// order with tracking paused.
+func OnCleanup(rs *ReactiveSystem, fn func()) {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+
+	current := rs.activeSub
+	if current == nil {
+		current = rs.activeScope
+	}
+	if current == nil {
+		panic("OnCleanup must be called from within an effect, effect scope or computed")
+	}
+
+	owner, ok := current.ref.(cleanupOwner)
+	if !ok {
+		panic("OnCleanup called from a node that can't own cleanups")
+	}
+	list := owner.cleanupList()
+	*list = append(*list, fn)
+	current.flags |= fHasCleanups
+}
+
+// Runs and clears the cleanups registered on the given subscriber.
+//
+// Cleanups run last-registered first with tracking paused so reads inside a
+// cleanup don't become dependencies of whatever is currently running.
+//
+// @param sub - The effect, effect scope or computed to clean up.
+func (rs *ReactiveSystem) runCleanups(sub *signal) {
+	if sub.flags&fHasCleanups == 0 {
+		return
+	}
+	sub.flags &^= fHasCleanups
+
+	list := sub.ref.(cleanupOwner).cleanupList()
+	cleanups := *list
+	*list = nil
+
+	prevSub, prevScope := rs.activeSub, rs.activeScope
+	rs.activeSub, rs.activeScope = nil, nil
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	rs.activeSub, rs.activeScope = prevSub, prevScope
+}
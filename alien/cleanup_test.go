@@ -0,0 +1,107 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnCleanupRunsBeforeRerunAndOnStop(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 0)
+	logs := []string{}
+
+	stop := alien.Effect(rs, func() error {
+		count.Value()
+		logs = append(logs, "run")
+		alien.OnCleanup(rs, func() {
+			logs = append(logs, "first cleanup")
+		})
+		alien.OnCleanup(rs, func() {
+			logs = append(logs, "second cleanup")
+		})
+		return nil
+	})
+	assert.Equal(t, []string{"run"}, logs)
+
+	count.SetValue(1)
+	assert.Equal(t, []string{"run", "second cleanup", "first cleanup", "run"}, logs)
+
+	logs = logs[:0]
+	stop()
+	assert.Equal(t, []string{"second cleanup", "first cleanup"}, logs)
+
+	logs = logs[:0]
+	count.SetValue(2)
+	stop()
+	assert.Empty(t, logs)
+}
+
+func TestOnCleanupRunsWhenScopeIsDisposed(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 0)
+	logs := []string{}
+
+	stopScope := alien.EffectScope(rs, func() error {
+		alien.OnCleanup(rs, func() {
+			logs = append(logs, "scope")
+		})
+		alien.Effect(rs, func() error {
+			count.Value()
+			alien.OnCleanup(rs, func() {
+				logs = append(logs, "effect")
+			})
+			return nil
+		})
+		return nil
+	})
+
+	count.SetValue(1)
+	assert.Equal(t, []string{"effect"}, logs)
+
+	logs = logs[:0]
+	stopScope()
+	assert.Equal(t, []string{"effect", "scope"}, logs)
+
+	logs = logs[:0]
+	count.SetValue(2)
+	assert.Empty(t, logs)
+}
+
+func TestOnCleanupInComputed(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	src := alien.Signal(rs, 1)
+	cleanups := 0
+	doubled := alien.Computed(rs, func(oldValue int) int {
+		alien.OnCleanup(rs, func() {
+			cleanups++
+		})
+		return src.Value() * 2
+	})
+
+	stop := alien.Effect(rs, func() error {
+		doubled.Value()
+		return nil
+	})
+	assert.Equal(t, 0, cleanups)
+
+	src.SetValue(2)
+	assert.Equal(t, 1, cleanups)
+
+	stop()
+	assert.Equal(t, 2, cleanups)
+}
+
+func TestOnCleanupOutsideEffectPanics(t *testing.T) {
+	rs := alien.CreateReactiveSystem(nil)
+	assert.Panics(t, func() {
+		alien.OnCleanup(rs, func() {})
+	})
+}
@@ -0,0 +1,127 @@
+package alien
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the time source behind Debounced, Throttled, Delayed, Interval
+// and Now.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls fn once d has passed, see time.AfterFunc.
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// Timer is a pending AfterFunc call.
+type Timer interface {
+	// Stop prevents the call and reports whether it was still pending.
+	Stop() bool
+}
+
+// WithClock sets the clock used by the time operators. The default is
+// RealClock.
+func WithClock(c Clock) Option {
+	return func(rs *ReactiveSystem) {
+		rs.clock = c
+	}
+}
+
+// RealClock uses the time package. Its timers fire on their own goroutines,
+// so a ReactiveSystem using it with time operators must be created
+// WithConcurrency, the operators panic otherwise.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (RealClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return time.AfterFunc(d, fn)
+}
+
+// FakeClock only moves when Advance is called. Timers fire synchronously on
+// the goroutine calling Advance, which makes tests deterministic.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	lastSeq uint64
+	timers  []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	seq   uint64
+	fn    func()
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, fn func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastSeq++
+	t := &fakeTimer{clock: c, at: c.now.Add(d), seq: c.lastSeq, fn: fn}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, firing every timer that comes due in
+// order, including timers scheduled by the timers it fires.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		next := -1
+		for i, t := range c.timers {
+			if t.at.After(end) {
+				continue
+			}
+			if next < 0 || t.at.Before(c.timers[next].at) ||
+				(t.at.Equal(c.timers[next].at) && t.seq < c.timers[next].seq) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		t := c.timers[next]
+		c.timers = append(c.timers[:next], c.timers[next+1:]...)
+		c.now = t.at
+
+		c.mu.Unlock()
+		t.fn()
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+// Pending returns the number of timers waiting to fire.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package alien
+
+// nodeOwner is implemented by collections that create nodes on demand and
+// want to forget them once nothing reads them any more.
+type nodeOwner interface {
+	releaseNode(n *signal)
+}
+
+// Creates a plain dependency node for one part of a reactive collection.
+//
+// @param owner - The collection the node belongs to.
+// @returns The new node.
+func (rs *ReactiveSystem) collectionNode(owner any) *signal {
+	n := &signal{ref: owner}
+	rs.registerNode(n)
+	return n
+}
+
+// Links a collection node to the active subscriber, if there is one.
+//
+// @param n - The node that was read.
+func (rs *ReactiveSystem) trackNode(n *signal) {
+	if rs.activeSub != nil {
+		rs.link(n, rs.activeSub)
+	}
+}
+
+// Propagates a write to the subscribers of a collection node.
+//
+// Effects are only queued, call flushWrite once every affected node has been
+// triggered.
+//
+// @param n - The node that changed, may be nil if it was never read.
+func (rs *ReactiveSystem) triggerNode(n *signal) {
+	if n == nil {
+		return
+	}
+	rs.writeEpoch++
+	n.changedAt = rs.writeEpoch
+	if n.subs != nil {
+		rs.propagate(n.subs)
+	}
+}
+
+// Runs the effects queued by triggerNode unless a batch is open.
+func (rs *ReactiveSystem) flushWrite() {
+	if rs.batchDepth == 0 {
+		rs.processEffectNotifications()
+	}
+}
@@ -1,56 +1,295 @@
 package alien
 
-type ReadonlySignal[T comparable] struct {
+type ReadonlySignal[T any] struct {
 	signal
 
 	rs     *ReactiveSystem
 	value  T
 	getter func(oldValue T) T
+	equals func(a, b T) bool
+
+	getterErr func(oldValue T) (T, error)
+	err       error
+	// first error of a failing computed the running getter read through Value
+	readErr error
+
+	cleanups []func()
+
+	detachedAt uint64
 }
 
 func (s *ReadonlySignal[T]) isSignalAware() {}
 
+func (s *ReadonlySignal[T]) cleanupList() *[]func() {
+	return &s.cleanups
+}
+
+// Value returns the current value, recomputing it first if needed.
+//
+// If the computed holds an error and isn't being read by another computed, the
+// error is reported to the system's OnErrorFunc. Computeds that want to pass
+// errors downstream should read through ValueErr instead.
 func (s *ReadonlySignal[T]) Value() T {
+	if s.rs.mu != nil {
+		return s.lockedValue()
+	}
+	s.refresh()
+	if s.err != nil {
+		s.reportErr()
+	}
+	return s.value
+}
+
+// Value under the system lock, kept out of Value as a deferred unlock slows
+// down the unlocked case.
+func (s *ReadonlySignal[T]) lockedValue() T {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	s.refresh()
+	if s.err != nil {
+		s.reportErr()
+	}
+	return s.value
+}
+
+// Hands the cached error of a computed being read through Value to the
+// reading computed, which fails with it, see compute. Other readers get it
+// reported to the OnErrorFunc.
+func (s *ReadonlySignal[T]) reportErr() {
+	if sub := s.rs.activeSub; sub != nil && sub.flags&fComputed != 0 {
+		sub.ref.(computedAny).readFailed(s.err)
+	} else if s.rs.onError != nil {
+		s.rs.onError(s, s.err)
+	}
+}
+
+// ValueErr returns the current value and the error cached by the last
+// recomputation. The error is cleared once a dependency change makes the
+// getter succeed again.
+func (s *ReadonlySignal[T]) ValueErr() (T, error) {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	s.refresh()
+	return s.value, s.err
+}
+
+func (s *ReadonlySignal[T]) refresh() {
+	rs := s.rs
 	flags := s.flags
+	if rs.hooked || flags&(fDetached|fTracking|fChecking) != 0 {
+		s.refreshHooked(flags)
+		return
+	}
 	signal := &s.signal
 	if flags&(fDirty|fPendingComputed) != 0 {
+		processComputedUpdate(rs, signal, flags)
+	}
+	if rs.cycleNode != nil && rs.reportCycle(signal) {
+		return
+	}
+	if rs.activeSub != nil {
+		rs.link(signal, rs.activeSub)
+	} else if rs.activeScope != nil {
+		rs.link(signal, rs.activeScope)
+	} else if signal.subs == nil {
+		rs.detach(signal)
+	}
+}
+
+// refresh for a detached computed, one read from within its own update or
+// while stats are collected.
+func (s *ReadonlySignal[T]) refreshHooked(flags subscriberFlags) {
+	signal := &s.signal
+	if flags&fDetached != 0 {
+		if s.rs.activeSub == nil && s.rs.activeScope == nil && s.detachedAt == s.rs.writeEpoch {
+			if s.rs.stats != nil {
+				s.rs.stats.ComputedHits++
+			}
+			return
+		}
+		s.rs.attach(signal)
+		flags = s.flags
+	}
+	if flags&(fTracking|fChecking) != 0 {
+		// read from within its own getter or while checking whether it is dirty
+		s.rs.cycleNode = signal
+	} else if flags&(fDirty|fPendingComputed) != 0 {
 		processComputedUpdate(s.rs, signal, flags)
+	} else if s.rs.stats != nil {
+		s.rs.stats.ComputedHits++
+	}
+	if s.rs.cycleNode != nil && s.rs.reportCycle(signal) {
+		return
 	}
 	if s.rs.activeSub != nil {
 		s.rs.link(signal, s.rs.activeSub)
 	} else if s.rs.activeScope != nil {
 		s.rs.link(signal, s.rs.activeScope)
+	} else if signal.subs == nil {
+		s.rs.detach(signal)
 	}
-
-	return s.value
 }
 
-func (s *ReadonlySignal[T]) cas() bool {
-	oldValue := s.value
-	newValue := s.getter(oldValue)
+// Runs the getter. A failing getter, or a failing computed it read through
+// Value, leaves the last good value in place and caches the error instead.
+func (s *ReadonlySignal[T]) compute() bool {
+	rs := s.rs
+	oldValue, oldErr := s.value, s.err
+	hits := rs.cycleHits
+
+	var (
+		newValue T
+		err      error
+	)
+	if s.getterErr != nil {
+		newValue, err = s.getterErr(oldValue)
+	} else {
+		newValue = s.getter(oldValue)
+	}
+	if s.readErr != nil {
+		if err == nil {
+			err = s.readErr
+		}
+		s.readErr = nil
+	}
+
+	if rs.cycleHits != hits && rs.onCycle(&s.signal) {
+		return rs.failCycle(&s.signal)
+	}
+	s.err = err
+	if err != nil {
+		// keep the last good value around, the error is what changed
+		s.changedAt = rs.writeEpoch
+		return true
+	}
 	s.value = newValue
-	return oldValue != newValue
+	if oldErr == nil && s.equals(oldValue, newValue) {
+		return false
+	}
+	s.changedAt = rs.writeEpoch
+	return true
 }
 
 func Computed[T comparable](rs *ReactiveSystem, getter func(oldValue T) T) *ReadonlySignal[T] {
+	return ComputedFunc(rs, getter, Equal[T])
+}
+
+// ComputedFunc creates a computed for any type, using equals to decide whether
+// a recomputation changed the value and downstream subscribers need to know.
+// A nil equals behaves like NeverEqual.
+func ComputedFunc[T any](rs *ReactiveSystem, getter func(oldValue T) T, equals func(a, b T) bool) *ReadonlySignal[T] {
+	if equals == nil {
+		equals = NeverEqual[T]
+	}
 	c := &ReadonlySignal[T]{
 		rs:     rs,
 		getter: getter,
+		equals: equals,
 		signal: signal{
 			flags: fComputed | fDirty,
 		},
 	}
 	signal := &c.signal
 	signal.ref = c
+	rs.registerNode(signal)
+	return c
+}
+
+// ComputedErr creates a computed whose getter can fail. The error is cached
+// like a value, see ReadonlySignal.ValueErr.
+func ComputedErr[T comparable](rs *ReactiveSystem, getter func(oldValue T) (T, error)) *ReadonlySignal[T] {
+	return ComputedErrFunc(rs, getter, Equal[T])
+}
+
+// ComputedErrFunc is ComputedErr for any type, using equals to decide whether
+// a successful recomputation changed the value.
+func ComputedErrFunc[T any](rs *ReactiveSystem, getter func(oldValue T) (T, error), equals func(a, b T) bool) *ReadonlySignal[T] {
+	c := ComputedFunc[T](rs, nil, equals)
+	c.getterErr = getter
 	return c
 }
 
 type computedAny interface {
-	cas() (wasDifferent bool)
+	compute() (wasDifferent bool)
+	fail(err error) (wasDifferent bool)
+	readFailed(err error)
+	detachedEpoch() *uint64
+	save() (restore func())
+}
+
+func (s *ReadonlySignal[T]) save() func() {
+	value, err := s.value, s.err
+	return func() {
+		s.value, s.err = value, err
+	}
+}
+
+func (s *ReadonlySignal[T]) detachedEpoch() *uint64 {
+	return &s.detachedAt
+}
+
+func (s *ReadonlySignal[T]) readFailed(err error) {
+	if s.readErr == nil {
+		s.readErr = err
+	}
+}
+
+func (s *ReadonlySignal[T]) fail(err error) bool {
+	s.err = err
+	s.readErr = nil
+	s.changedAt = s.rs.writeEpoch
+	return true
 }
 
 func updateComputed(rs *ReactiveSystem, signal *signal) bool {
+	if rs.hooked || signal.flags&(fTracking|fHasCleanups) != 0 {
+		return updateComputedHooked(rs, signal)
+	}
+	prevSub := rs.activeSub
+	rs.activeSub = signal
+	rs.startTracking(signal)
+
+	defer func() {
+		rs.activeSub = prevSub
+		rs.endTracking(signal)
+	}()
+
+	return signal.ref.(computedAny).compute()
+}
+
+// updateComputed for a computed read from within its own update, one with
+// cleanups to run or while tracing, stats, transactions or panic recovery are
+// on.
+func updateComputedHooked(rs *ReactiveSystem, signal *signal) (wasDifferent bool) {
+	if signal.flags&fTracking != 0 {
+		rs.cycleNode = signal
+		return false
+	}
+	if rs.stats != nil {
+		rs.stats.ComputedRuns++
+	}
+	if rs.transactions != nil {
+		rs.saveComputed(signal)
+	}
+	if rs.tracer != nil {
+		rs.tracer.ComputeStart(rs.traceNode(signal))
+		defer func() {
+			rs.tracer.ComputeEnd(rs.traceNode(signal), wasDifferent)
+		}()
+	}
+	rs.runCleanups(signal)
 	prevSub := rs.activeSub
+	if rs.recoverPanics {
+		state := rs.saveTracking()
+		defer func() {
+			if r := recover(); r != nil {
+				rs.restoreTracking(state)
+				wasDifferent = signal.ref.(computedAny).fail(newPanicError(r))
+			}
+		}()
+	}
 	rs.activeSub = signal
 	rs.startTracking(signal)
 
@@ -59,7 +298,7 @@ func updateComputed(rs *ReactiveSystem, signal *signal) bool {
 		rs.endTracking(signal)
 	}()
 
-	return signal.ref.(computedAny).cas()
+	return signal.ref.(computedAny).compute()
 }
 
 // Updates the computed subscriber if necessary before its value is accessed.
@@ -71,8 +310,15 @@ func updateComputed(rs *ReactiveSystem, signal *signal) bool {
 // @param computed - The computed subscriber to update.
 // @param flags - The current flag set for this subscriber.
 func processComputedUpdate(rs *ReactiveSystem, signal *signal, flags subscriberFlags) {
+	hits := rs.cycleHits
 	if flags&fDirty != 0 || rs.checkDirty(signal.deps) {
-		if updateComputed(rs, signal) {
+		var changed bool
+		if rs.cycleHits != hits && rs.onCycle(signal) {
+			changed = rs.failCycle(signal)
+		} else {
+			changed = updateComputed(rs, signal)
+		}
+		if changed {
 			subs := signal.subs
 			if subs != nil {
 				rs.shallowPropagate(subs)
@@ -80,5 +326,8 @@ func processComputedUpdate(rs *ReactiveSystem, signal *signal, flags subscriberF
 		}
 	} else {
 		signal.flags = flags & ^fPendingComputed
+		if rs.stats != nil {
+			rs.stats.ComputedHits++
+		}
 	}
 }
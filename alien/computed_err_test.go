@@ -0,0 +1,154 @@
+package alien_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNegative = errors.New("negative")
+
+func TestComputedErrCachesAndClearsError(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	src := alien.Signal(rs, 1)
+	getterRuns := 0
+	checked := alien.ComputedErr(rs, func(oldValue int) (int, error) {
+		getterRuns++
+		v := src.Value()
+		if v < 0 {
+			return 0, errNegative
+		}
+		return v, nil
+	})
+
+	v, err := checked.ValueErr()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	src.SetValue(-1)
+	v, err = checked.ValueErr()
+	assert.ErrorIs(t, err, errNegative)
+	assert.Equal(t, 1, v, "last good value is kept")
+
+	_, err = checked.ValueErr()
+	assert.ErrorIs(t, err, errNegative)
+	assert.Equal(t, 2, getterRuns, "error is cached until a dependency changes")
+
+	src.SetValue(5)
+	v, err = checked.ValueErr()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, v)
+}
+
+func TestComputedErrPropagatesDownstream(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	src := alien.Signal(rs, 1)
+	checked := alien.ComputedErr(rs, func(oldValue int) (int, error) {
+		if src.Value() < 0 {
+			return 0, errNegative
+		}
+		return src.Value(), nil
+	})
+	doubled := alien.ComputedErr(rs, func(oldValue int) (int, error) {
+		v, err := checked.ValueErr()
+		if err != nil {
+			return 0, err
+		}
+		return v * 2, nil
+	})
+
+	src.SetValue(-3)
+	_, err := doubled.ValueErr()
+	assert.ErrorIs(t, err, errNegative)
+
+	src.SetValue(3)
+	v, err := doubled.ValueErr()
+	assert.NoError(t, err)
+	assert.Equal(t, 6, v)
+}
+
+func TestComputedErrRoutesToOnErrorFromEffects(t *testing.T) {
+	var (
+		reported []error
+		fromNode alien.SignalAware
+	)
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		fromNode = from
+		reported = append(reported, err)
+	})
+
+	src := alien.Signal(rs, 1)
+	checked := alien.ComputedErr(rs, func(oldValue int) (int, error) {
+		if src.Value() < 0 {
+			return 0, errNegative
+		}
+		return src.Value(), nil
+	})
+
+	effectRuns := 0
+	alien.Effect(rs, func() error {
+		effectRuns++
+		checked.Value()
+		return nil
+	})
+	assert.Empty(t, reported)
+
+	src.SetValue(-1)
+	assert.Equal(t, 2, effectRuns)
+	assert.Equal(t, []error{errNegative}, reported)
+	assert.Same(t, checked, fromNode)
+
+	src.SetValue(2)
+	assert.Equal(t, 3, effectRuns)
+	assert.Len(t, reported, 1)
+}
+
+func TestComputedErrReachesPlainComputedReaders(t *testing.T) {
+	var (
+		reported []error
+		fromNode alien.SignalAware
+	)
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		fromNode = from
+		reported = append(reported, err)
+	})
+
+	src := alien.Signal(rs, 1)
+	checked := alien.ComputedErr(rs, func(oldValue int) (int, error) {
+		if src.Value() < 0 {
+			return 0, errNegative
+		}
+		return src.Value(), nil
+	})
+	plusOne := alien.Computed(rs, func(oldValue int) int {
+		return checked.Value() + 1
+	})
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, plusOne.Value())
+		return nil
+	})
+
+	src.SetValue(-1)
+	v, err := plusOne.ValueErr()
+	assert.ErrorIs(t, err, errNegative)
+	assert.Equal(t, 2, v, "last good value is kept")
+	assert.Equal(t, []error{errNegative}, reported)
+	assert.Same(t, plusOne, fromNode)
+
+	src.SetValue(4)
+	v, err = plusOne.ValueErr()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, v)
+	assert.Equal(t, []int{2, 2, 5}, seen)
+	assert.Len(t, reported, 1)
+}
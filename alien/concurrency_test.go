@@ -0,0 +1,77 @@
+package alien_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentValueAndSetValue(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency())
+
+	count := alien.Signal(rs, 0)
+	doubled := alien.Computed(rs, func(oldValue int) int {
+		return count.Value() * 2
+	})
+
+	effectRuns := 0
+	lastSeen := 0
+	alien.Effect(rs, func() error {
+		effectRuns++
+		lastSeen = doubled.Value()
+		return nil
+	})
+
+	const goroutines, iters = 16, 200
+	wg := sync.WaitGroup{}
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iters; j++ {
+				rs.Batch(func() {
+					count.SetValue(count.Value() + 1)
+				})
+				doubled.Value()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, goroutines*iters, count.Value())
+	assert.Equal(t, goroutines*iters*2, doubled.Value())
+	assert.Equal(t, goroutines*iters*2, lastSeen)
+	assert.Equal(t, goroutines*iters+1, effectRuns)
+}
+
+func TestConcurrentEffectsCanWriteSignals(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency())
+
+	src := alien.Signal(rs, 0)
+	mirror := alien.Signal(rs, 0)
+	alien.Effect(rs, func() error {
+		mirror.SetValue(src.Value())
+		return nil
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				src.SetValue(v*1000 + j)
+				mirror.Value()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, src.Value(), mirror.Value())
+}
@@ -0,0 +1,31 @@
+package alien
+
+import "context"
+
+// WithContext sets the root context of the system. The contexts handed to
+// EffectCtx and AsyncComputed are derived from it, so cancelling it cancels
+// all of them. The default is context.Background.
+func WithContext(ctx context.Context) Option {
+	return func(rs *ReactiveSystem) {
+		rs.ctx = ctx
+	}
+}
+
+// Context returns the root context of the system, see WithContext.
+func (rs *ReactiveSystem) Context() context.Context {
+	return rs.ctx
+}
+
+// EffectCtx is Effect for effects that start work which should be aborted
+// once its result is no longer wanted.
+//
+// Every run gets a fresh context derived from the system's root context. It is
+// cancelled right before the next run, when the returned ErrFn is called and
+// when an enclosing EffectScope is disposed.
+func EffectCtx(rs *ReactiveSystem, fn func(ctx context.Context) error) ErrFn {
+	return Effect(rs, func() error {
+		ctx, cancel := context.WithCancel(rs.ctx)
+		OnCleanup(rs, cancel)
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,107 @@
+package alien_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectCtxCancelledBeforeRerun(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	ctxs := []context.Context{}
+	stop := alien.EffectCtx(rs, func(ctx context.Context) error {
+		count.Value()
+		ctxs = append(ctxs, ctx)
+		return nil
+	})
+	require.Len(t, ctxs, 1)
+	assert.NoError(t, ctxs[0].Err())
+
+	count.SetValue(2)
+	require.Len(t, ctxs, 2)
+	assert.ErrorIs(t, ctxs[0].Err(), context.Canceled)
+	assert.NoError(t, ctxs[1].Err())
+
+	require.NoError(t, stop())
+	assert.ErrorIs(t, ctxs[1].Err(), context.Canceled)
+}
+
+func TestEffectCtxCancelledWithScope(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	var got context.Context
+	stop := alien.EffectScope(rs, func() error {
+		alien.EffectCtx(rs, func(ctx context.Context) error {
+			got = ctx
+			return nil
+		})
+		return nil
+	})
+	assert.NoError(t, got.Err())
+
+	require.NoError(t, stop())
+	assert.ErrorIs(t, got.Err(), context.Canceled)
+}
+
+func TestEffectCtxReportsErrors(t *testing.T) {
+	errs := []error{}
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs = append(errs, err)
+	})
+
+	boom := errors.New("boom")
+	alien.EffectCtx(rs, func(ctx context.Context) error {
+		return boom
+	})
+	assert.Equal(t, []error{boom}, errs)
+}
+
+func TestRootContextCancelsEverything(t *testing.T) {
+	root, cancel := context.WithCancel(context.Background())
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithContext(root))
+	assert.Equal(t, root, rs.Context())
+
+	ctxs := []context.Context{}
+	for range 3 {
+		alien.EffectCtx(rs, func(ctx context.Context) error {
+			ctxs = append(ctxs, ctx)
+			return nil
+		})
+	}
+
+	cancel()
+	for _, ctx := range ctxs {
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	}
+}
+
+func TestRootContextCancelsAsyncLoads(t *testing.T) {
+	root, cancel := context.WithCancel(context.Background())
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency(), alien.WithContext(root))
+
+	done := make(chan error)
+	alien.AsyncComputed(rs, func() int {
+		return 1
+	}, func(ctx context.Context, key int) (int, error) {
+		<-ctx.Done()
+		done <- ctx.Err()
+		return 0, ctx.Err()
+	})
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
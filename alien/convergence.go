@@ -0,0 +1,137 @@
+package alien
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultConvergenceLimit is how many times an effect may run in a single
+// flush unless WithConvergenceLimit says otherwise.
+const DefaultConvergenceLimit = 100
+
+// WithConvergenceLimit sets how many times a single effect may run while a
+// Scheduler flushes effects, in one rs.Flush or rs.RunEffects call.
+//
+// Effects that write signals their own dependencies depend on can keep
+// notifying each other forever when a scheduler queues them up again. Once an
+// effect goes over the limit it is skipped for the rest of the flush, so the
+// flush ends, and a *ConvergenceError is reported to the OnErrorFunc. A limit
+// of 0 or less disables the check.
+//
+// Only runs started by the flush itself count. Effects run synchronously by a
+// write inside another effect don't.
+//
+// Without a scheduler nothing is counted and nothing is reported: writes run
+// effects synchronously, and an effect that is still running is never run
+// again, so effects writing each other can't spin. They stop after one round
+// instead. When e1 writes y, which runs e2, which writes x that e1 reads, e2
+// sees e1's write but e1 never sees e2's: it is left Dirty with values that
+// disagree until a write to one of its dependencies runs it again. Use a Scheduler if such
+// effects must settle, or be told when they don't.
+func WithConvergenceLimit(limit int) Option {
+	return func(rs *ReactiveSystem) {
+		rs.maxEffectRuns = limit
+	}
+}
+
+// ConvergenceError reports effects that didn't settle within the
+// convergence limit, together with the signals they wrote to each other
+// during the flush.
+type ConvergenceError struct {
+	Limit   int
+	Effects []TraceNode
+	Signals []TraceNode
+}
+
+func (e *ConvergenceError) Error() string {
+	names := func(nodes []TraceNode) string {
+		parts := make([]string, len(nodes))
+		for i, n := range nodes {
+			parts[i] = n.Name()
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprintf(
+		"alien: effects did not settle after %d runs: effects [%s], signals [%s]",
+		e.Limit, names(e.Effects), names(e.Signals),
+	)
+}
+
+// Marks the start of a scheduler flush. Nested calls belong to the outermost
+// flush.
+func (rs *ReactiveSystem) startFlush() {
+	if rs.flushDepth == 0 {
+		rs.flushID++
+		rs.flushReported = false
+		rs.settlingEffects = rs.settlingEffects[:0]
+	}
+	rs.flushDepth++
+	rs.hooked = true
+}
+
+func (rs *ReactiveSystem) endFlush() {
+	if rs.flushDepth--; rs.flushDepth == 0 {
+		rs.updateHooked()
+	}
+}
+
+// Counts a run of an effect against the convergence limit of the current
+// flush.
+//
+// @param e - The effect about to run.
+// @returns false if the effect went over the limit and must be skipped.
+func (rs *ReactiveSystem) countEffectRun(e *EffectRunner) bool {
+	if rs.maxEffectRuns <= 0 {
+		return true
+	}
+	if e.flushID != rs.flushID {
+		e.flushID = rs.flushID
+		e.flushRuns = 0
+	}
+	e.flushRuns++
+	if e.flushRuns == rs.maxEffectRuns {
+		rs.settlingEffects = append(rs.settlingEffects, &e.signal)
+	}
+	if e.flushRuns <= rs.maxEffectRuns {
+		return true
+	}
+
+	if !rs.flushReported {
+		rs.flushReported = true
+		if rs.onError != nil {
+			rs.onError(e, rs.convergenceError())
+		}
+	}
+	return false
+}
+
+// Builds the error for the effects that reached the limit in this flush.
+//
+// The signals are the plain signals those effects depend on, directly or
+// through computeds, that were written during the flush.
+func (rs *ReactiveSystem) convergenceError() *ConvergenceError {
+	err := &ConvergenceError{Limit: rs.maxEffectRuns}
+	seen := map[*signal]bool{}
+	var visit func(n *signal)
+	visit = func(n *signal) {
+		for l := n.deps; l != nil; l = l.nextDep {
+			dep := l.dep
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if dep.flags&fComputed != 0 {
+				visit(dep)
+				continue
+			}
+			if w, ok := dep.ref.(interface{ lastWriteFlush() uint64 }); ok && w.lastWriteFlush() == rs.flushID {
+				err.Signals = append(err.Signals, rs.traceNode(dep))
+			}
+		}
+	}
+	for _, e := range rs.settlingEffects {
+		err.Effects = append(err.Effects, rs.traceNode(e))
+		visit(e)
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+package alien_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvergenceLimitStopsPingPong(t *testing.T) {
+	errs := []error{}
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs = append(errs, err)
+	}, alien.WithScheduler(alien.NewManualScheduler()), alien.WithConvergenceLimit(10))
+
+	a := alien.Signal(rs, 0)
+	b := alien.Signal(rs, 0)
+	a.SetLabel("a")
+	b.SetLabel("b")
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		alien.Label(rs, "a to b")
+		runs++
+		b.SetValue(a.Value() + 1)
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		alien.Label(rs, "b to a")
+		runs++
+		a.SetValue(b.Value() + 1)
+		return nil
+	})
+
+	rs.Flush()
+	require.Len(t, errs, 1)
+	convergenceErr := &alien.ConvergenceError{}
+	require.ErrorAs(t, errs[0], &convergenceErr)
+	assert.Equal(t, 10, convergenceErr.Limit)
+
+	names := []string{}
+	for _, n := range convergenceErr.Effects {
+		names = append(names, n.Name())
+	}
+	assert.ElementsMatch(t, []string{"a to b", "b to a"}, names)
+	names = names[:0]
+	for _, n := range convergenceErr.Signals {
+		names = append(names, n.Name())
+	}
+	assert.ElementsMatch(t, []string{"a", "b"}, names)
+	assert.Contains(t, errs[0].Error(), "a to b")
+	assert.LessOrEqual(t, runs, 22)
+
+	// the next flush gets a fresh budget
+	a.SetValue(1000)
+	rs.Flush()
+	assert.Len(t, errs, 2)
+}
+
+func TestConvergenceLimitPerTick(t *testing.T) {
+	scheduler := alien.NewTickerScheduler(5 * time.Millisecond)
+	defer scheduler.Stop()
+	errs := make(chan error, 100)
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs <- err
+	}, alien.WithConcurrency(), alien.WithScheduler(scheduler), alien.WithConvergenceLimit(10))
+
+	a := alien.Signal(rs, 0)
+	b := alien.Signal(rs, 0)
+	runs := atomic.Int32{}
+	alien.Effect(rs, func() error {
+		runs.Add(1)
+		b.SetValue(a.Value() + 1)
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		runs.Add(1)
+		a.SetValue(b.Value() + 1)
+		return nil
+	})
+
+	select {
+	case err := <-errs:
+		convergenceErr := &alien.ConvergenceError{}
+		require.ErrorAs(t, err, &convergenceErr)
+	case <-time.After(time.Second):
+		require.FailNow(t, "no ConvergenceError reported")
+	}
+
+	// the effects that went over the limit were skipped, nothing is left to
+	// schedule them again
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, runs.Load(), int32(22))
+	assert.Empty(t, errs)
+}
+
+func TestConvergenceLimitAllowsSettlingEffects(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithScheduler(alien.NewManualScheduler()), alien.WithConvergenceLimit(10))
+
+	a := alien.Signal(rs, 0)
+	b := alien.Signal(rs, 0)
+	alien.Effect(rs, func() error {
+		if v := a.Value(); v < 5 {
+			b.SetValue(v + 1)
+		}
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		a.SetValue(b.Value())
+		return nil
+	})
+
+	rs.Flush()
+	assert.Equal(t, 5, a.Value())
+}
+
+func TestConvergenceLimitIgnoresNestedRuns(t *testing.T) {
+	for name, opts := range map[string][]alien.Option{
+		"sync":           nil,
+		"sync scheduler": {alien.WithScheduler(alien.SyncScheduler{})},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+				assert.FailNow(t, err.Error())
+			}, opts...)
+
+			start := alien.Signal(rs, 0)
+			count := alien.Signal(rs, 0)
+			alien.Effect(rs, func() error {
+				base := start.Value()
+				for i := 1; i <= 150; i++ {
+					count.SetValue(base + i)
+				}
+				return nil
+			})
+			reads := 0
+			last := 0
+			alien.Effect(rs, func() error {
+				reads++
+				last = count.Value()
+				return nil
+			})
+			assert.Equal(t, 150, last)
+
+			reads = 0
+			start.SetValue(1000)
+			assert.Equal(t, 1150, last)
+			assert.Equal(t, 150, reads)
+		})
+	}
+}
+
+func TestEffectsWritingEachOtherStopWithoutScheduler(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConvergenceLimit(10))
+
+	x := alien.Signal(rs, 0)
+	y := alien.Signal(rs, 0)
+	alien.Effect(rs, func() error {
+		y.SetValue(x.Value() + 1)
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		x.SetValue(y.Value() + 1)
+		return nil
+	})
+
+	// the first effect is still running when the second writes x, so it
+	// doesn't see that write and nothing is reported
+	x.SetValue(5)
+	assert.Equal(t, 7, x.Value())
+	assert.Equal(t, 6, y.Value())
+}
@@ -0,0 +1,183 @@
+package alien
+
+import (
+	"slices"
+	"strings"
+)
+
+// CycleError is the error of every computed on a dependency cycle, found
+// when a computed is read, directly or through other computeds, while it is
+// being computed.
+//
+// The computeds keep their last value and recompute as usual once a
+// dependency changes, so the graph recovers when the cycle is broken.
+type CycleError struct {
+	// Path lists the nodes of the cycle in read order, starting and ending
+	// with the same node.
+	Path []TraceNode
+}
+
+func (e *CycleError) Error() string {
+	names := make([]string, len(e.Path))
+	for i, n := range e.Path {
+		names[i] = n.Name()
+	}
+	return "alien: dependency cycle: " + strings.Join(names, " -> ")
+}
+
+// Records a cycle found while refreshing a computed.
+//
+// rs.cycleNode is the computed that was reached again: either one that is
+// being computed or one checkDirty is still checking. Nothing tracks the
+// computeds in between up front, so the cycle is only pending at first: while
+// the stack unwinds towards the target every computed returning in between
+// fails with the same *CycleError, see onCycle, and the path is complete once
+// the target itself failed. The computeds from read to the target are
+// not being computed and fail right away.
+//
+// @param read - The computed being read.
+// @returns `true` if read must not be linked to the active subscriber, as its
+// dependencies are still being walked.
+func (rs *ReactiveSystem) reportCycle(read *signal) (skipLink bool) {
+	target := rs.cycleNode
+	rs.cycleNode = nil
+	if reader := rs.activeSub; reader == nil || reader.flags&(fComputed|fTracking) != fComputed|fTracking {
+		return false
+	}
+
+	tail := []*signal{target}
+	if read != target {
+		if tail = findDepPath(read, target, map[*signal]bool{}); tail == nil {
+			tail = []*signal{read, target}
+		}
+	}
+	if rs.cycle == nil {
+		rs.cycle = &CycleError{}
+		rs.cycleTail = tail
+	}
+	for _, n := range tail[:len(tail)-1] {
+		n.ref.(computedAny).fail(rs.cycle)
+	}
+	rs.cycleTargets = append(rs.cycleTargets, target)
+	rs.cycleHits++
+	return target.flags&fChecking != 0
+}
+
+// Reports whether a computed is on the cycle found while it was being
+// computed or checked.
+//
+// While the cycle is pending everything between the read and its target is,
+// as that is what unwinds towards the target. Once it is complete only the
+// computeds on its path are, which may be computed again on the way back up,
+// e.g. a computed refreshed while a node it depends on is computed.
+//
+// @param node - A computed that was computed or checked while rs.cycleHits
+// went up.
+func (rs *ReactiveSystem) onCycle(node *signal) bool {
+	if rs.cycle != nil {
+		return true
+	}
+	if c := rs.lastCycle; c != nil {
+		id := rs.nodeID(node)
+		for _, n := range c.Path {
+			if n.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Fails a computed on the cycle, keeping its last value. A pending cycle is
+// complete once its last target failed.
+//
+// @param node - A computed for which onCycle holds.
+// @returns `true`, the error changed.
+func (rs *ReactiveSystem) failCycle(node *signal) bool {
+	node.flags &^= fDirty | fPendingComputed
+	if rs.cycle == nil {
+		return node.ref.(computedAny).fail(rs.lastCycle)
+	}
+	if n := len(rs.cyclePath); n == 0 || rs.cyclePath[n-1] != node {
+		// refreshing a computed fails it again right after it was computed
+		rs.cyclePath = append(rs.cyclePath, node)
+	}
+	changed := node.ref.(computedAny).fail(rs.cycle)
+	if i := slices.Index(rs.cycleTargets, node); i >= 0 {
+		rs.cycleTargets = slices.Delete(rs.cycleTargets, i, i+1)
+		if len(rs.cycleTargets) == 0 {
+			rs.endCycle()
+		}
+	}
+	return changed
+}
+
+// Fills in the path of the pending cycle: the computeds in the order they
+// failed while unwinding, outermost first, followed by the ones from the read
+// computed to the target.
+func (rs *ReactiveSystem) endCycle() {
+	nodes := slices.Concat(rs.cyclePath, rs.cycleTail)
+	slices.Reverse(nodes[:len(rs.cyclePath)])
+	path := make([]TraceNode, len(nodes))
+	for i, n := range nodes {
+		path[i] = rs.traceNode(n)
+	}
+	rs.cycle.Path = path
+	rs.lastCycle = rs.cycle
+	rs.dropCycle()
+}
+
+// Forgets the pending cycle.
+func (rs *ReactiveSystem) dropCycle() {
+	rs.cycle = nil
+	clear(rs.cyclePath)
+	rs.cyclePath = rs.cyclePath[:0]
+	rs.cycleTargets = rs.cycleTargets[:0]
+	rs.cycleTail = nil
+}
+
+// Clears the Checking flag of the computeds checkDirty descended into and
+// didn't get back up to, returning the link stack to the pool.
+//
+// @param current - A link whose sub is the innermost remaining computed.
+// @param prevLinks - The link stack of checkDirty.
+// @param checkDepth - How many computeds remain.
+func (rs *ReactiveSystem) endChecks(current *link, prevLinks *OneWayLink_link, checkDepth int) {
+	for ; checkDepth != 0; checkDepth-- {
+		computed := current.sub
+		computed.flags &^= fChecking
+		firstSub := computed.subs
+		if firstSub == nil || firstSub.nextSub != nil && prevLinks == nil {
+			// the links changed underneath, only cycles do that
+			break
+		}
+		if firstSub.nextSub != nil {
+			current, prevLinks = rs.popLink(prevLinks)
+		} else {
+			current = firstSub
+		}
+	}
+	rs.releaseLinkStack(prevLinks)
+}
+
+// Finds a chain of dependencies leading from one node to another.
+//
+// @param from - The node to start at.
+// @param to - The node to reach.
+// @param seen - The nodes already visited.
+// @returns The nodes from `from` to `to`, both included, or nil.
+func findDepPath(from, to *signal, seen map[*signal]bool) []*signal {
+	if from == to {
+		return []*signal{to}
+	}
+	seen[from] = true
+	for l := from.deps; l != nil; l = l.nextDep {
+		if seen[l.dep] {
+			continue
+		}
+		if rest := findDepPath(l.dep, to, seen); rest != nil {
+			return append([]*signal{from}, rest...)
+		}
+	}
+	return nil
+}
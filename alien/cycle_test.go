@@ -0,0 +1,203 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycleReportsPath(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	var b *alien.ReadonlySignal[int]
+	a := alien.Computed(rs, func(oldValue int) int {
+		return b.Value() + 1
+	})
+	b = alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	a.SetLabel("a")
+	b.SetLabel("b")
+
+	_, err := a.ValueErr()
+	cycleErr := &alien.CycleError{}
+	require.ErrorAs(t, err, &cycleErr)
+	names := []string{}
+	for _, n := range cycleErr.Path {
+		names = append(names, n.Name())
+	}
+	assert.Equal(t, []string{"a", "b", "a"}, names)
+	assert.Equal(t, "alien: dependency cycle: a -> b -> a", err.Error())
+
+	_, err = b.ValueErr()
+	assert.ErrorAs(t, err, &cycleErr)
+}
+
+func TestCycleSelfRead(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	var a *alien.ReadonlySignal[int]
+	a = alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	a.SetLabel("a")
+
+	_, err := a.ValueErr()
+	assert.EqualError(t, err, "alien: dependency cycle: a -> a")
+}
+
+func TestCycleThroughPendingComputeds(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	cyclic := alien.Signal(rs, false)
+	var c *alien.ReadonlySignal[int]
+	a := alien.Computed(rs, func(oldValue int) int {
+		if cyclic.Value() {
+			return c.Value() + 1
+		}
+		return 1
+	})
+	b := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	c = alien.Computed(rs, func(oldValue int) int {
+		return b.Value() + 1
+	})
+	a.SetLabel("a")
+	b.SetLabel("b")
+	c.SetLabel("c")
+
+	assert.Equal(t, 3, c.Value())
+
+	cyclic.SetValue(true)
+	_, err := a.ValueErr()
+	assert.EqualError(t, err, "alien: dependency cycle: a -> c -> b -> a")
+}
+
+func TestCycleGraphRecovers(t *testing.T) {
+	errs := []error{}
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs = append(errs, err)
+	})
+
+	cyclic := alien.Signal(rs, false)
+	var b *alien.ReadonlySignal[int]
+	a := alien.Computed(rs, func(oldValue int) int {
+		if cyclic.Value() {
+			return b.Value() + 1
+		}
+		return 1
+	})
+	b = alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, b.Value())
+		return nil
+	})
+	assert.Equal(t, []int{2}, seen)
+	assert.Empty(t, errs)
+
+	cyclic.SetValue(true)
+	_, err := a.ValueErr()
+	cycleErr := &alien.CycleError{}
+	require.ErrorAs(t, err, &cycleErr)
+
+	cyclic.SetValue(false)
+	v, err := a.ValueErr()
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	v, err = b.ValueErr()
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 2, seen[len(seen)-1])
+}
+
+func TestCycleReachedFromEffect(t *testing.T) {
+	errs := []error{}
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs = append(errs, err)
+	})
+
+	toggle := alien.Signal(rs, false)
+	var b *alien.ReadonlySignal[int]
+	a := alien.Computed(rs, func(oldValue int) int {
+		if toggle.Value() {
+			return b.Value() + 1
+		}
+		return 1
+	})
+	b = alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	a.SetLabel("a")
+	b.SetLabel("b")
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		runs++
+		b.Value()
+		return nil
+	})
+
+	toggle.SetValue(true)
+	assert.Equal(t, 2, runs)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "alien: dependency cycle: b -> a -> b")
+
+	// both keep their last value
+	v, err := a.ValueErr()
+	assert.Same(t, errs[0], err)
+	assert.Equal(t, 1, v)
+	v, err = b.ValueErr()
+	assert.Same(t, errs[0], err)
+	assert.Equal(t, 2, v)
+}
+
+func TestCycleFailsEveryComputedOnPath(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	toggle := alien.Signal(rs, false)
+	var c *alien.ReadonlySignal[int]
+	a := alien.Computed(rs, func(oldValue int) int {
+		if toggle.Value() {
+			return c.Value() + 1
+		}
+		return 1
+	})
+	b := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	c = alien.Computed(rs, func(oldValue int) int {
+		return b.Value() + 1
+	})
+	alien.EffectScope(rs, func() error {
+		alien.Effect(rs, func() error {
+			c.ValueErr()
+			return nil
+		})
+		return nil
+	})
+
+	toggle.SetValue(true)
+	_, err := c.ValueErr()
+	cycleErr := &alien.CycleError{}
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Len(t, cycleErr.Path, 4)
+	for _, s := range []*alien.ReadonlySignal[int]{a, b} {
+		_, err := s.ValueErr()
+		assert.Same(t, cycleErr, err)
+	}
+}
@@ -0,0 +1,218 @@
+package alien
+
+// Derived is a read-only view over a ReactiveSlice kept up to date
+// incrementally, see MapKeyed, Filter and Reduce.
+type Derived[T any] struct {
+	*ReadonlySignal[T]
+	stop ErrFn
+}
+
+// Stop stops updating the view and disposes any per-item scopes.
+func (d *Derived[T]) Stop() error {
+	return d.stop()
+}
+
+type keyedItem[T any, U any] struct {
+	item  *WriteableSignal[T]
+	index *WriteableSignal[int]
+	value U
+	stop  ErrFn
+}
+
+// MapKeyed maps every element of list through mapFn, reusing the result for
+// elements whose key stays in the list.
+//
+// mapFn runs once per key, untracked, inside its own Scope. It gets the
+// element and its index as signals, so effects and computeds it creates follow
+// the element when it is replaced by one with the same key or moves. The scope
+// is disposed once the key leaves the list.
+func MapKeyed[T any, K comparable, U any](
+	rs *ReactiveSystem,
+	list *ReactiveSlice[T],
+	key func(item T) K,
+	mapFn func(item Source[T], index Source[int]) U,
+) *Derived[[]U] {
+	entries, stop := reconcileKeyed(rs, list, key, mapFn)
+	values := ComputedFunc(rs, func(oldValue []U) []U {
+		items := entries.Value()
+		values := make([]U, len(items))
+		for i, e := range items {
+			values[i] = e.value
+		}
+		return values
+	}, nil)
+	return &Derived[[]U]{ReadonlySignal: values, stop: stop}
+}
+
+// Filter keeps the elements of list for which pred returns true.
+//
+// pred is wrapped in a computed per key, so a write only re-evaluates pred for
+// the elements that changed. Signals read by pred are tracked as well.
+func Filter[T any, K comparable](
+	rs *ReactiveSystem,
+	list *ReactiveSlice[T],
+	key func(item T) K,
+	pred func(item T) bool,
+) *Derived[[]T] {
+	entries, stop := reconcileKeyed(rs, list, key, func(item Source[T], index Source[int]) *ReadonlySignal[bool] {
+		return Computed(rs, func(oldValue bool) bool {
+			return pred(item.Value())
+		})
+	})
+	kept := ComputedFunc(rs, func(oldValue []T) []T {
+		var kept []T
+		for _, e := range entries.Value() {
+			if e.value.Value() {
+				kept = append(kept, e.item.Value())
+			}
+		}
+		return kept
+	}, nil)
+	return &Derived[[]T]{ReadonlySignal: kept, stop: stop}
+}
+
+// Reduce folds list into a single value with reducer.
+//
+// When inverse is given, a write is applied by taking the removed elements
+// back out with inverse and folding in the added ones, independent of the
+// length of the list. Without it every write folds the whole list again.
+// reducer and inverse are not tracked.
+func Reduce[T any, A comparable](
+	rs *ReactiveSystem,
+	list *ReactiveSlice[T],
+	initial A,
+	reducer func(acc A, item T) A,
+	inverse func(acc A, item T) A,
+) *Derived[A] {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	fold := func() A {
+		acc := initial
+		for _, v := range list.items {
+			acc = reducer(acc, v)
+		}
+		return acc
+	}
+
+	acc := Signal(rs, fold())
+	l := list.listen(func(removed, added []T) {
+		if inverse == nil {
+			acc.SetValue(fold())
+			return
+		}
+		v := acc.value
+		for _, item := range removed {
+			v = inverse(v, item)
+		}
+		for _, item := range added {
+			v = reducer(v, item)
+		}
+		acc.SetValue(v)
+	})
+
+	value := Computed(rs, func(oldValue A) A {
+		return acc.Value()
+	})
+	return &Derived[A]{
+		ReadonlySignal: value,
+		stop: func() error {
+			if rs.mu != nil {
+				rs.mu.Lock()
+				defer rs.mu.Unlock()
+			}
+			list.unlisten(l)
+			return nil
+		},
+	}
+}
+
+// Keeps one keyedItem per key of list, creating and disposing them as keys
+// enter and leave.
+//
+// Everything lives in a Scope created in the caller's scope, so the items go
+// away together with it.
+//
+// Duplicate keys each get their own item, matched up in order.
+//
+// @param list - The list to follow.
+// @param key - Returns the key of an element.
+// @param mapFn - Creates the value of a new item inside its scope.
+// @returns A signal holding the items in list order and a function that stops
+// following list.
+func reconcileKeyed[T any, K comparable, U any](
+	rs *ReactiveSystem,
+	list *ReactiveSlice[T],
+	key func(item T) K,
+	mapFn func(item Source[T], index Source[int]) U,
+) (*WriteableSignal[[]*keyedItem[T, U]], ErrFn) {
+	entries := SignalFunc(rs, nil, func(a, b []*keyedItem[T, U]) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	})
+	byKey := map[K][]*keyedItem[T, U]{}
+	owner := NewScope(rs)
+
+	newItem := func(v T, i int) *keyedItem[T, U] {
+		e := &keyedItem[T, U]{
+			item:  SignalFunc(rs, v, list.equals),
+			index: Signal(rs, i),
+		}
+		var scope *Scope
+		owner.Run(func() error {
+			scope = NewScope(rs)
+			return nil
+		})
+		scope.Run(func() error {
+			e.value = mapFn(e.item, e.index)
+			return nil
+		})
+		e.stop = scope.Stop
+		return e
+	}
+
+	err := owner.Run(func() error {
+		Effect(rs, func() error {
+			items := list.Slice()
+			next := make(map[K][]*keyedItem[T, U], len(byKey))
+			order := make([]*keyedItem[T, U], 0, len(items))
+
+			rs.Batch(func() {
+				for i, v := range items {
+					k := key(v)
+					var e *keyedItem[T, U]
+					if queue := byKey[k]; len(queue) > 0 {
+						e, byKey[k] = queue[0], queue[1:]
+						e.item.SetValue(v)
+						e.index.SetValue(i)
+					} else {
+						e = newItem(v, i)
+					}
+					next[k] = append(next[k], e)
+					order = append(order, e)
+				}
+				for _, queue := range byKey {
+					for _, e := range queue {
+						e.stop()
+					}
+				}
+				byKey = next
+				entries.SetValue(order)
+			})
+			return nil
+		})
+		return nil
+	})
+	if err != nil && rs.onError != nil {
+		rs.onError(owner, err)
+	}
+	return entries, owner.Stop
+}
@@ -0,0 +1,140 @@
+package alien_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+type todo struct {
+	ID   int
+	Text string
+	Done bool
+}
+
+func todoID(t todo) int {
+	return t.ID
+}
+
+func TestMapKeyedReusesItemsByKey(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	todos := alien.NewReactiveSlice(rs, []todo{{1, "a", false}, {2, "b", false}})
+
+	created, disposed := 0, []int{}
+	labels := alien.MapKeyed(rs, todos, todoID, func(item alien.Source[todo], index alien.Source[int]) *alien.ReadonlySignal[string] {
+		created++
+		id := item.Value().ID
+		alien.OnCleanup(rs, func() {
+			disposed = append(disposed, id)
+		})
+		return alien.Computed(rs, func(oldValue string) string {
+			return strings.Repeat(" ", index.Value()) + item.Value().Text
+		})
+	})
+	texts := func() []string {
+		out := []string{}
+		for _, label := range labels.Value() {
+			out = append(out, label.Value())
+		}
+		return out
+	}
+	assert.Equal(t, []string{"a", " b"}, texts())
+	assert.Equal(t, 2, created)
+
+	todos.Set(0, todo{1, "A", false})
+	assert.Equal(t, []string{"A", " b"}, texts())
+	assert.Equal(t, 2, created, "same key, the item is reused")
+
+	todos.Splice(0, 0, todo{3, "c", false})
+	assert.Equal(t, []string{"c", " A", "  b"}, texts())
+	assert.Equal(t, 3, created)
+
+	todos.Splice(1, 1)
+	assert.Equal(t, []string{"c", " b"}, texts())
+	assert.Equal(t, []int{1}, disposed)
+
+	labels.Stop()
+	assert.ElementsMatch(t, []int{1, 2, 3}, disposed)
+}
+
+func TestFilterOnlyReevaluatesChangedItems(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	todos := alien.NewReactiveSlice(rs, []todo{{1, "a", false}, {2, "b", true}, {3, "c", false}})
+
+	predRuns := 0
+	open := alien.Filter(rs, todos, todoID, func(item todo) bool {
+		predRuns++
+		return !item.Done
+	})
+	assert.Equal(t, []todo{{1, "a", false}, {3, "c", false}}, open.Value())
+	assert.Equal(t, 3, predRuns)
+
+	todos.Set(2, todo{3, "c", true})
+	assert.Equal(t, []todo{{1, "a", false}}, open.Value())
+	assert.Equal(t, 4, predRuns)
+
+	todos.Splice(0, 0, todo{4, "d", false})
+	assert.Equal(t, []todo{{4, "d", false}, {1, "a", false}}, open.Value())
+	assert.Equal(t, 5, predRuns)
+
+	todos.Splice(1, 1)
+	assert.Equal(t, []todo{{4, "d", false}}, open.Value())
+	assert.Equal(t, 5, predRuns)
+}
+
+func TestReduceWithInverse(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	nums := alien.NewReactiveSlice(rs, []int{1, 2, 3})
+
+	adds, subs := 0, 0
+	sum := alien.Reduce(rs, nums, 0, func(acc, item int) int {
+		adds++
+		return acc + item
+	}, func(acc, item int) int {
+		subs++
+		return acc - item
+	})
+	assert.Equal(t, 6, sum.Value())
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, sum.Value())
+		return nil
+	})
+
+	adds = 0
+	nums.Append(4)
+	nums.Set(0, 10)
+	nums.Splice(1, 2, 5)
+	assert.Equal(t, 19, sum.Value())
+	assert.Equal(t, []int{6, 10, 19}, seen)
+	assert.Equal(t, 3, adds)
+	assert.Equal(t, 3, subs)
+
+	sum.Stop()
+	nums.Append(100)
+	assert.Equal(t, 19, sum.Value())
+}
+
+func TestReduceWithoutInverseRefolds(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	nums := alien.NewReactiveSlice(rs, []int{3, 1, 2})
+
+	largest := alien.Reduce(rs, nums, 0, func(acc, item int) int {
+		return max(acc, item)
+	}, nil)
+	assert.Equal(t, 3, largest.Value())
+
+	nums.Splice(0, 1)
+	assert.Equal(t, 2, largest.Value())
+}
@@ -0,0 +1,84 @@
+package alien
+
+// Takes an unobserved computed out of the subs lists of its dependencies,
+// detaching dependencies that are left without subscribers as well.
+//
+// A computed read outside of any effect, scope or computed still tracks its
+// dependencies, but left in their subs lists it would stay reachable from
+// long-lived signals forever. Once detached only the computed points at the
+// graph, so the garbage collector reclaims it when the caller drops it. It
+// keeps its own deps list and the write epoch it was detached at, which attach
+// uses to tell whether it is still up to date.
+//
+// Computeds with OnCleanup callbacks stay linked so the callbacks still run.
+//
+// @param c - The computed to detach, which must have no subscribers.
+func (rs *ReactiveSystem) detach(c *signal) {
+	if c.deps == nil || c.flags&(fDetached|fHasCleanups) != 0 {
+		return
+	}
+	for l := c.deps; l != nil; l = l.nextDep {
+		dep := l.dep
+		if l.nextSub != nil {
+			l.nextSub.prevSub = l.prevSub
+		} else {
+			dep.subsTail = l.prevSub
+		}
+		if l.prevSub != nil {
+			l.prevSub.nextSub = l.nextSub
+		} else {
+			dep.subs = l.nextSub
+		}
+		l.prevSub, l.nextSub = nil, nil
+		if rs.stats != nil {
+			rs.stats.Links--
+		}
+		if rs.tracer != nil {
+			rs.tracer.Unlink(rs.traceNode(dep), rs.traceNode(c))
+		}
+
+		if dep.subs == nil && dep.flags&fComputed != 0 {
+			rs.detach(dep)
+		}
+	}
+	c.flags |= fDetached
+	*c.ref.(computedAny).detachedEpoch() = rs.writeEpoch
+}
+
+// Links a detached computed back into the subs lists of its dependencies.
+//
+// Writes made while it was detached never reached it, so it is marked Dirty if
+// a dependency changed since, or PendingComputed if a computed dependency may
+// still change, including one that is being computed right now.
+//
+// @param c - The detached computed.
+func (rs *ReactiveSystem) attach(c *signal) {
+	flags := c.flags &^ fDetached
+	detachedAt := *c.ref.(computedAny).detachedEpoch()
+	for l := c.deps; l != nil; l = l.nextDep {
+		dep := l.dep
+		if dep.flags&fDetached != 0 {
+			rs.attach(dep)
+		}
+		l.prevSub = dep.subsTail
+		if dep.subsTail != nil {
+			dep.subsTail.nextSub = l
+		} else {
+			dep.subs = l
+		}
+		dep.subsTail = l
+		if rs.stats != nil {
+			rs.stats.Links++
+		}
+		if rs.tracer != nil {
+			rs.tracer.Link(rs.traceNode(dep), rs.traceNode(c))
+		}
+
+		if dep.changedAt > detachedAt {
+			flags |= fDirty
+		} else if dep.flags&fComputed != 0 && dep.flags&(fDirty|fPendingComputed|fTracking) != 0 {
+			flags |= fPendingComputed
+		}
+	}
+	c.flags = flags
+}
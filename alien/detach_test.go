@@ -0,0 +1,152 @@
+package alien_test
+
+import (
+	"runtime"
+	"testing"
+	"weak"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collected[T any](p weak.Pointer[T]) bool {
+	for range 5 {
+		runtime.GC()
+		if p.Value() == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUnobservedComputedIsCollected(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	p := func() weak.Pointer[alien.ReadonlySignal[int]] {
+		double := alien.Computed(rs, func(oldValue int) int {
+			return count.Value() * 2
+		})
+		assert.Equal(t, 2, double.Value())
+		return weak.Make(double)
+	}()
+
+	assert.Empty(t, rs.Inspect(count).Subs)
+	assert.True(t, collected(p))
+	count.SetValue(2)
+}
+
+func TestUnobservedComputedChainsAreCollected(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	const n = 1000
+	pointers := make([]weak.Pointer[alien.ReadonlySignal[int]], 0, n)
+	func() {
+		for i := range n {
+			inner := alien.Computed(rs, func(oldValue int) int {
+				return count.Value() + i
+			})
+			outer := alien.Computed(rs, func(oldValue int) int {
+				return inner.Value() * 2
+			})
+			assert.Equal(t, (1+i)*2, outer.Value())
+			pointers = append(pointers, weak.Make(inner), weak.Make(outer))
+		}
+	}()
+
+	runtime.GC()
+	for _, p := range pointers {
+		require.True(t, collected(p))
+	}
+	assert.Empty(t, rs.Inspect(count).Subs)
+}
+
+func TestObservedComputedIsKept(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	seen := 0
+	var p weak.Pointer[alien.ReadonlySignal[int]]
+	stop := func() alien.ErrFn {
+		double := alien.Computed(rs, func(oldValue int) int {
+			return count.Value() * 2
+		})
+		p = weak.Make(double)
+		return alien.Effect(rs, func() error {
+			seen = double.Value()
+			return nil
+		})
+	}()
+
+	assert.False(t, collected(p))
+	count.SetValue(2)
+	assert.Equal(t, 4, seen)
+
+	require.NoError(t, stop())
+	assert.True(t, collected(p))
+}
+
+func TestDetachedComputedStaysCurrent(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+	other := alien.Signal(rs, 1)
+
+	runs := 0
+	double := alien.Computed(rs, func(oldValue int) int {
+		runs++
+		return count.Value() * 2
+	})
+	assert.Equal(t, 2, double.Value())
+	assert.Equal(t, 2, double.Value())
+	assert.Equal(t, 1, runs)
+
+	other.SetValue(2)
+	assert.Equal(t, 2, double.Value())
+	assert.Equal(t, 1, runs)
+
+	count.SetValue(3)
+	assert.Equal(t, 6, double.Value())
+	assert.Equal(t, 2, runs)
+
+	seen := 0
+	alien.Effect(rs, func() error {
+		seen = double.Value()
+		return nil
+	})
+	assert.Equal(t, 2, runs)
+	count.SetValue(4)
+	assert.Equal(t, 8, seen)
+	assert.Equal(t, 3, runs)
+}
+
+func TestGraphSkipsCollectedNodes(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithIntrospection())
+	count := alien.Signal(rs, 1)
+	count.SetLabel("count")
+
+	p := func() weak.Pointer[alien.ReadonlySignal[int]] {
+		double := alien.Computed(rs, func(oldValue int) int {
+			return count.Value() * 2
+		})
+		double.Value()
+		return weak.Make(double)
+	}()
+
+	require.True(t, collected(p))
+	graph := rs.Graph()
+	require.Len(t, graph, 1)
+	assert.Equal(t, "count", graph[0].Label)
+	runtime.KeepAlive(count)
+}
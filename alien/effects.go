@@ -3,14 +3,28 @@ package alien
 type ErrFn func() error
 
 func Effect(rs *ReactiveSystem, fn ErrFn) ErrFn {
+	return rs.effect(fn, 0)
+}
+
+// Creates an effect, runs it once and returns the function that stops it.
+//
+// @param fn - The effect function.
+// @param flags - Extra flags such as fFlushPre or fFlushPost.
+// @returns A function that stops the effect.
+func (rs *ReactiveSystem) effect(fn ErrFn, flags subscriberFlags) ErrFn {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
 	e := &EffectRunner{
 		fn: fn,
 		signal: signal{
-			flags: fEffect,
+			flags: fEffect | flags,
 		},
 	}
 	signal := &e.signal
 	signal.ref = e
+	rs.registerNode(signal)
 
 	if rs.activeSub != nil {
 		rs.link(signal, rs.activeSub)
@@ -20,15 +34,54 @@ func Effect(rs *ReactiveSystem, fn ErrFn) ErrFn {
 	rs.runEffect(e, signal)
 
 	return func() error {
+		if rs.mu != nil {
+			rs.mu.Lock()
+			defer rs.mu.Unlock()
+		}
 		rs.startTracking(signal)
 		rs.endTracking(signal)
+		rs.runCleanups(signal)
 		return nil
 	}
 }
 
 func (rs *ReactiveSystem) runEffect(e *EffectRunner, signal *signal) {
+	if rs.hooked || signal.flags&fHasCleanups != 0 {
+		rs.runEffectHooked(e, signal)
+		return
+	}
+	prevSub := rs.activeSub
+	rs.activeSub = signal
+	rs.startTracking(signal)
+	if err := e.fn(); err != nil {
+		if rs.onError != nil {
+			rs.onError(e, err)
+		}
+	}
+	rs.endTracking(signal)
+	rs.activeSub = prevSub
+}
+
+// runEffect for an effect with cleanups to run or while tracing, stats, panic
+// recovery or a flush is going on.
+func (rs *ReactiveSystem) runEffectHooked(e *EffectRunner, signal *signal) {
+	if rs.flushDepth > 0 && rs.effectDepth == 0 && !rs.countEffectRun(e) {
+		signal.flags &^= fDirty | fPendingComputed
+		return
+	}
+	if rs.tracer != nil {
+		rs.tracer.EffectRun(rs.traceNode(signal))
+	}
+	if rs.stats != nil {
+		rs.stats.EffectRuns++
+	}
+	rs.runCleanups(signal)
 	prevSub := rs.activeSub
+	if rs.recoverPanics {
+		defer rs.recoverEffect(e, signal, rs.saveTracking())
+	}
 	rs.activeSub = signal
+	rs.effectDepth++
 	rs.startTracking(signal)
 	if err := e.fn(); err != nil {
 		if rs.onError != nil {
@@ -36,12 +89,19 @@ func (rs *ReactiveSystem) runEffect(e *EffectRunner, signal *signal) {
 		}
 	}
 	rs.endTracking(signal)
+	rs.effectDepth--
 	rs.activeSub = prevSub
 }
 
 func (rs *ReactiveSystem) notifyEffect(signal *signal) bool {
 	flags := signal.flags
 	if flags&fEffectScope != 0 {
+		if flags&fPaused != 0 {
+			// keep PendingEffect set, Scope.Resume replays it. Clear Notified
+			// here as a scope reached through its parent is never dequeued.
+			signal.flags = flags &^ fNotified
+			return false
+		}
 		if flags&fPendingEffect != 0 {
 			rs.processPendingInnerEffects(signal, flags)
 			return true
@@ -58,42 +118,56 @@ func (rs *ReactiveSystem) notifyEffect(signal *signal) bool {
 	return true
 }
 
+// EffectScope runs scopedFn in a new Scope and returns the function that
+// disposes it. See NewScope for more control over the scope.
 func EffectScope(rs *ReactiveSystem, scopedFn ErrFn) (stopScope ErrFn) {
-	e := &EffectRunner{
-		signal: signal{
-			flags: fEffect | fEffectScope,
-		},
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
 	}
-	signal := &e.signal
-	signal.ref = e
-	rs.runEffectScope(e, signal, scopedFn)
-	return func() error {
-		rs.startTracking(signal)
-		rs.endTracking(signal)
-		return nil
+	s := NewScope(rs)
+	if err := s.Run(scopedFn); err != nil {
+		if rs.onError != nil {
+			rs.onError(s, err)
+		}
 	}
+	return s.Stop
 }
 
 type EffectRunner struct {
 	signal
-	fn ErrFn
+	fn       ErrFn
+	cleanups []func()
+
+	flushID   uint64
+	flushRuns int
 }
 
 func (e *EffectRunner) isSignalAware() {}
 
-func (rs *ReactiveSystem) runEffectScope(e *EffectRunner, signal *signal, scopedFn ErrFn) {
-	prevSub := rs.activeSub
-	rs.activeScope = signal
-	rs.startTracking(signal)
+func (e *EffectRunner) cleanupList() *[]func() {
+	return &e.cleanups
+}
 
-	if err := scopedFn(); err != nil {
-		if rs.onError != nil {
-			rs.onError(e, err)
-		}
+// Recovers a panic raised while running an effect.
+//
+// Must be deferred directly. The half-built dependency list is closed at the
+// last dependency tracked before the panic and the panic is reported to the
+// OnErrorFunc as a *PanicError.
+//
+// @param e - The effect that was running.
+// @param signal - The subscriber node of e.
+// @param state - The tracking state captured before e started running.
+func (rs *ReactiveSystem) recoverEffect(e *EffectRunner, signal *signal, state trackingState) {
+	r := recover()
+	if r == nil {
+		return
 	}
-
-	rs.activeScope = prevSub
 	rs.endTracking(signal)
+	rs.restoreTracking(state)
+	if rs.onError != nil {
+		rs.onError(e, newPanicError(r))
+	}
 }
 
 // Ensures all pending internal effects for the given subscriber are processed.
@@ -103,22 +177,39 @@ func (rs *ReactiveSystem) runEffectScope(e *EffectRunner, signal *signal, scoped
 // PendingEffect, this function clears that flag and invokes `notifyEffect` on any
 // related dependencies marked as Effect and Propagated, processing pending effects.
 //
+// Inner effects keep their flush timing: pre-flush ones run ahead of their
+// siblings and post-flush ones are queued, so they run after every other
+// effect of the flush like top-level ones.
+//
 // @param sub - The subscriber which may have pending effects.
 // @param flags - The current flags on the subscriber to check.
 func (rs *ReactiveSystem) processPendingInnerEffects(sub *signal, flags subscriberFlags) {
 	if flags&fPendingEffect != 0 {
 		sub.flags = flags & ^fPendingEffect
-		link := sub.deps
-		for {
+		hasPlain := false
+		for link := sub.deps; link != nil; link = link.nextDep {
 			dep := link.dep
 			flags = dep.flags
-			if flags&fEffect != 0 && flags&fPropagated != 0 {
+			if flags&fEffect == 0 || flags&fPropagated == 0 {
+				continue
+			}
+			switch {
+			case flags&fFlushPre != 0:
 				rs.notifyEffect(dep)
+			case flags&fFlushPost != 0:
+				rs.queueEffect(dep)
+			default:
+				hasPlain = true
 			}
-			link = link.nextDep
-
-			if link == nil {
-				break
+		}
+		if !hasPlain {
+			return
+		}
+		for link := sub.deps; link != nil; link = link.nextDep {
+			dep := link.dep
+			flags = dep.flags
+			if flags&fEffect != 0 && flags&fPropagated != 0 && flags&(fFlushPre|fFlushPost) == 0 {
+				rs.notifyEffect(dep)
 			}
 		}
 	}
@@ -130,12 +221,12 @@ func (rs *ReactiveSystem) processPendingInnerEffects(sub *signal, flags subscrib
 // If an effect remains partially handled, its flags are updated, and future
 // notifications may be triggered until fully handled.
 func (rs *ReactiveSystem) processEffectNotifications() {
-	for rs.queuedEffects != nil {
-		effect := rs.queuedEffects.target
-		rs.queuedEffects = rs.queuedEffects.linked
-		if rs.queuedEffects == nil {
-			rs.queuedEffectsTail = nil
-		}
+	if rs.scheduler != nil {
+		rs.scheduleEffectNotifications()
+		return
+	}
+	for rs.hasQueuedEffects() {
+		effect := rs.dequeueEffect()
 		if !rs.notifyEffect(effect) {
 			effect.flags = effect.flags & ^fNotified
 		}
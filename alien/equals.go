@@ -0,0 +1,21 @@
+package alien
+
+import "reflect"
+
+// Equal reports whether a and b are equal using ==. It is the equality used by
+// Signal and Computed.
+func Equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+// DeepEqual reports whether a and b are equal using reflect.DeepEqual, useful
+// for slices, maps and structs containing them.
+func DeepEqual[T any](a, b T) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// NeverEqual treats every write or recomputation as a change, so subscribers
+// are always notified.
+func NeverEqual[T any](a, b T) bool {
+	return false
+}
@@ -0,0 +1,74 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalFuncHoldsSlices(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	items := alien.SignalFunc(rs, []string{"a"}, alien.DeepEqual[[]string])
+	effectRuns := 0
+	alien.Effect(rs, func() error {
+		effectRuns++
+		items.Value()
+		return nil
+	})
+	assert.Equal(t, 1, effectRuns)
+
+	items.SetValue([]string{"a"})
+	assert.Equal(t, 1, effectRuns)
+
+	items.SetValue([]string{"a", "b"})
+	assert.Equal(t, 2, effectRuns)
+	assert.Equal(t, []string{"a", "b"}, items.Value())
+}
+
+func TestComputedFuncCutsOffWithCustomEquality(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	src := alien.Signal(rs, 1)
+	parity := alien.ComputedFunc(rs, func(oldValue map[string]bool) map[string]bool {
+		return map[string]bool{"even": src.Value()%2 == 0}
+	}, alien.DeepEqual[map[string]bool])
+
+	effectRuns := 0
+	alien.Effect(rs, func() error {
+		effectRuns++
+		parity.Value()
+		return nil
+	})
+	assert.Equal(t, 1, effectRuns)
+
+	src.SetValue(3)
+	assert.Equal(t, 1, effectRuns)
+
+	src.SetValue(4)
+	assert.Equal(t, 2, effectRuns)
+	assert.Equal(t, map[string]bool{"even": true}, parity.Value())
+}
+
+func TestNeverEqualAlwaysNotifies(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	tick := alien.SignalFunc(rs, struct{}{}, alien.NeverEqual[struct{}])
+	effectRuns := 0
+	alien.Effect(rs, func() error {
+		effectRuns++
+		tick.Value()
+		return nil
+	})
+
+	tick.SetValue(struct{}{})
+	tick.SetValue(struct{}{})
+	assert.Equal(t, 3, effectRuns)
+}
@@ -0,0 +1,254 @@
+package alien
+
+import "time"
+
+// History records writes to a set of tracked signals as undoable steps.
+//
+// Every outermost batch is one step, as is every write made outside of a
+// batch, together with the writes of the effects it triggers. Writing the
+// same signal several times within a step only keeps its first old and last
+// new value.
+type History struct {
+	rs       *ReactiveSystem
+	tracked  map[*signal]bool
+	depth    int
+	coalesce time.Duration
+
+	undo, redo []historyStep
+	current    []historyWrite
+	open       int
+	applying   bool
+
+	canUndo, canRedo *WriteableSignal[bool]
+}
+
+type historyStep struct {
+	writes []historyWrite
+	at     time.Time
+}
+
+type historyWrite struct {
+	target             writeTarget
+	oldValue, newValue any
+}
+
+type historyConfig struct {
+	depth    int
+	coalesce time.Duration
+}
+
+type HistoryOption func(c *historyConfig)
+
+// HistoryDepth limits how many steps can be undone, dropping the oldest. The
+// default is 100, 0 means unlimited.
+func HistoryDepth(depth int) HistoryOption {
+	return func(c *historyConfig) {
+		c.depth = depth
+	}
+}
+
+// HistoryCoalesce merges a step into the previous one when both only wrote
+// the same single signal and happened within window of each other on the
+// system Clock, so typing into a field undoes as a whole.
+func HistoryCoalesce(window time.Duration) HistoryOption {
+	return func(c *historyConfig) {
+		c.coalesce = window
+	}
+}
+
+// NewHistory starts recording writes to the signals passed to Track.
+func NewHistory(rs *ReactiveSystem, opts ...HistoryOption) *History {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	cfg := historyConfig{depth: 100}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	h := &History{
+		rs:       rs,
+		tracked:  map[*signal]bool{},
+		depth:    cfg.depth,
+		coalesce: cfg.coalesce,
+		canUndo:  Signal(rs, false),
+		canRedo:  Signal(rs, false),
+	}
+	rs.addRecorder(h)
+	return h
+}
+
+// Track adds signals to the history. Only WriteableSignals can be tracked.
+func (h *History) Track(signals ...SignalAware) {
+	if h.rs.mu != nil {
+		h.rs.mu.Lock()
+		defer h.rs.mu.Unlock()
+	}
+	for _, s := range signals {
+		if _, ok := s.(writeTarget); !ok {
+			panic("History can only track WriteableSignals")
+		}
+		h.tracked[s.node()] = true
+	}
+}
+
+// Stop stops recording. Steps recorded so far can still be undone.
+func (h *History) Stop() {
+	if h.rs.mu != nil {
+		h.rs.mu.Lock()
+		defer h.rs.mu.Unlock()
+	}
+	h.rs.removeRecorder(h)
+}
+
+// CanUndo reports whether there is a step to undo, tracked like a signal
+// read.
+func (h *History) CanUndo() bool {
+	return h.canUndo.Value()
+}
+
+// CanRedo reports whether there is an undone step to redo, tracked like a
+// signal read.
+func (h *History) CanRedo() bool {
+	return h.canRedo.Value()
+}
+
+// Undo writes back the old values of the last step in a single batch and
+// reports whether there was a step to undo.
+func (h *History) Undo() bool {
+	if h.rs.mu != nil {
+		h.rs.mu.Lock()
+		defer h.rs.mu.Unlock()
+	}
+	if len(h.undo) == 0 {
+		return false
+	}
+	step := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.apply(func() {
+		for i := len(step.writes) - 1; i >= 0; i-- {
+			w := step.writes[i]
+			w.target.restore(w.oldValue)
+		}
+	})
+	h.redo = append(h.redo, step)
+	h.updateFlags()
+	return true
+}
+
+// Redo writes the new values of the last undone step again in a single
+// batch and reports whether there was a step to redo.
+func (h *History) Redo() bool {
+	if h.rs.mu != nil {
+		h.rs.mu.Lock()
+		defer h.rs.mu.Unlock()
+	}
+	if len(h.redo) == 0 {
+		return false
+	}
+	step := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.apply(func() {
+		for _, w := range step.writes {
+			w.target.restore(w.newValue)
+		}
+	})
+	h.undo = append(h.undo, step)
+	h.updateFlags()
+	return true
+}
+
+// Clear forgets every step.
+func (h *History) Clear() {
+	if h.rs.mu != nil {
+		h.rs.mu.Lock()
+		defer h.rs.mu.Unlock()
+	}
+	h.undo, h.redo = nil, nil
+	h.updateFlags()
+}
+
+func (h *History) apply(writes func()) {
+	h.applying = true
+	defer func() {
+		h.applying = false
+	}()
+	h.rs.Batch(writes)
+}
+
+func (h *History) updateFlags() {
+	h.rs.Batch(func() {
+		h.canUndo.SetValue(len(h.undo) > 0)
+		h.canRedo.SetValue(len(h.redo) > 0)
+	})
+}
+
+func (h *History) recordWrite(target writeTarget, oldValue, newValue any) {
+	if h.applying || !h.tracked[target.node()] {
+		return
+	}
+	for i := range h.current {
+		if h.current[i].target == target {
+			h.current[i].newValue = newValue
+			return
+		}
+	}
+	h.current = append(h.current, historyWrite{
+		target:   target,
+		oldValue: oldValue,
+		newValue: newValue,
+	})
+}
+
+func (h *History) batchStarted() {
+	if !h.applying {
+		h.open++
+	}
+}
+
+func (h *History) batchEnded() {
+	if h.applying || h.open == 0 {
+		return
+	}
+	h.open--
+	if h.open > 0 {
+		return
+	}
+	// writes that were set back, e.g. by a rolled back Transaction, are no step
+	writes := h.current[:0]
+	for _, w := range h.current {
+		if !w.target.same(w.oldValue, w.newValue) {
+			writes = append(writes, w)
+		}
+	}
+	h.current = nil
+	if len(writes) > 0 {
+		h.commit(writes)
+	}
+}
+
+// Pushes a finished step onto the undo stack, merging it into the previous
+// step if coalescing applies, and forgets the undone steps.
+//
+// @param writes - The writes of the step, at most one per signal.
+func (h *History) commit(writes []historyWrite) {
+	now := h.rs.clock.Now()
+	h.redo = nil
+
+	if h.coalesce > 0 && len(writes) == 1 && len(h.undo) > 0 {
+		last := &h.undo[len(h.undo)-1]
+		if len(last.writes) == 1 && last.writes[0].target == writes[0].target &&
+			now.Sub(last.at) < h.coalesce {
+			last.writes[0].newValue = writes[0].newValue
+			last.at = now
+			h.updateFlags()
+			return
+		}
+	}
+
+	h.undo = append(h.undo, historyStep{writes: writes, at: now})
+	if h.depth > 0 && len(h.undo) > h.depth {
+		h.undo = append(h.undo[:0], h.undo[len(h.undo)-h.depth:]...)
+	}
+	h.updateFlags()
+}
@@ -0,0 +1,144 @@
+package alien_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryUndoRedoBatches(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	x := alien.Signal(rs, 0)
+	y := alien.Signal(rs, 0)
+	untracked := alien.Signal(rs, 0)
+	h := alien.NewHistory(rs)
+	h.Track(x, y)
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		runs++
+		x.Value()
+		y.Value()
+		return nil
+	})
+
+	x.SetValue(1)
+	rs.Batch(func() {
+		x.SetValue(2)
+		x.SetValue(3)
+		y.SetValue(3)
+	})
+	untracked.SetValue(1)
+	assert.True(t, h.CanUndo())
+	assert.False(t, h.CanRedo())
+
+	runs = 0
+	assert.True(t, h.Undo())
+	assert.Equal(t, 1, runs, "undo writes in one batch")
+	assert.Equal(t, []int{1, 0}, []int{x.Value(), y.Value()})
+	assert.Equal(t, 1, untracked.Value())
+
+	assert.True(t, h.Undo())
+	assert.Equal(t, 0, x.Value())
+	assert.False(t, h.CanUndo())
+	assert.False(t, h.Undo())
+
+	assert.True(t, h.Redo())
+	assert.True(t, h.Redo())
+	assert.Equal(t, []int{3, 3}, []int{x.Value(), y.Value()})
+	assert.False(t, h.Redo())
+
+	h.Undo()
+	assert.True(t, h.CanRedo())
+	x.SetValue(10)
+	assert.False(t, h.CanRedo(), "a new step drops the undone ones")
+}
+
+func TestHistoryFlagsAreReactive(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	x := alien.Signal(rs, 0)
+	h := alien.NewHistory(rs)
+	h.Track(x)
+
+	states := [][2]bool{}
+	alien.Effect(rs, func() error {
+		states = append(states, [2]bool{h.CanUndo(), h.CanRedo()})
+		return nil
+	})
+
+	x.SetValue(1)
+	h.Undo()
+	h.Redo()
+	assert.Equal(t, [][2]bool{
+		{false, false},
+		{true, false},
+		{false, true},
+		{true, false},
+	}, states)
+}
+
+func TestHistoryIncludesEffectWrites(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	celsius := alien.Signal(rs, 0)
+	lastChange := alien.Signal(rs, "")
+	h := alien.NewHistory(rs)
+	h.Track(celsius, lastChange)
+
+	alien.Effect(rs, func() error {
+		if c := celsius.Value(); c != 0 {
+			lastChange.SetValue("set")
+		}
+		return nil
+	})
+
+	celsius.SetValue(20)
+	assert.Equal(t, "set", lastChange.Value())
+	h.Undo()
+	assert.Equal(t, 0, celsius.Value())
+	assert.Equal(t, "", lastChange.Value())
+	assert.False(t, h.CanUndo())
+}
+
+func TestHistoryDepthAndCoalescing(t *testing.T) {
+	clock := alien.NewFakeClock(time.Unix(0, 0))
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithClock(clock))
+	text := alien.Signal(rs, "")
+	other := alien.Signal(rs, 0)
+	h := alien.NewHistory(rs, alien.HistoryDepth(2), alien.HistoryCoalesce(time.Second))
+	h.Track(text, other)
+
+	text.SetValue("h")
+	clock.Advance(100 * time.Millisecond)
+	text.SetValue("he")
+	clock.Advance(100 * time.Millisecond)
+	text.SetValue("hey")
+	clock.Advance(2 * time.Second)
+	text.SetValue("hey!")
+
+	h.Undo()
+	assert.Equal(t, "hey", text.Value())
+	h.Undo()
+	assert.Equal(t, "", text.Value(), "rapid writes coalesce into one step")
+	h.Redo()
+	h.Redo()
+
+	other.SetValue(1)
+	clock.Advance(2 * time.Second)
+	other.SetValue(2)
+	text.SetValue("bye")
+	assert.True(t, h.Undo())
+	assert.True(t, h.Undo())
+	assert.False(t, h.Undo(), "only the last two steps are kept")
+	assert.Equal(t, "hey!", text.Value())
+	assert.Equal(t, 1, other.Value())
+}
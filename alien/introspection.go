@@ -0,0 +1,170 @@
+package alien
+
+import "weak"
+
+// NodeID identifies a node within its ReactiveSystem. IDs are handed out the
+// first time a node is inspected and never change afterwards.
+type NodeID uint64
+
+type NodeKind uint8
+
+const (
+	KindSignal NodeKind = iota
+	KindComputed
+	KindEffect
+	KindEffectScope
+)
+
+func (k NodeKind) String() string {
+	switch k {
+	case KindSignal:
+		return "signal"
+	case KindComputed:
+		return "computed"
+	case KindEffect:
+		return "effect"
+	case KindEffectScope:
+		return "effectScope"
+	default:
+		return "unknown"
+	}
+}
+
+// NodeInfo is a point-in-time description of a node in the graph.
+type NodeInfo struct {
+	ID              NodeID
+	Label           string
+	Kind            NodeKind
+	Dirty           bool
+	PendingComputed bool
+	PendingEffect   bool
+	Deps            []NodeID
+	Subs            []NodeID
+}
+
+// Name returns the label of the node, or its kind and id when unlabelled.
+func (n NodeInfo) Name() string {
+	return nodeName(n.ID, n.Label, n.Kind)
+}
+
+// WithIntrospection records every node created by the ReactiveSystem so
+// Graph can describe the whole system. The record only holds weak pointers,
+// so it doesn't keep collectable nodes alive.
+func WithIntrospection() Option {
+	return func(rs *ReactiveSystem) {
+		rs.trackNodes = true
+	}
+}
+
+// SetLabel attaches a human readable label shown by Inspect and Graph.
+func (s *signal) SetLabel(label string) {
+	s.label = label
+}
+
+// Label labels the effect, effect scope or computed that is currently
+// running. It is the way to label effects and scopes, whose constructors only
+// return a stop function.
+func Label(rs *ReactiveSystem, label string) {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+
+	current := rs.activeSub
+	if current == nil {
+		current = rs.activeScope
+	}
+	if current == nil {
+		panic("Label must be called from within an effect, effect scope or computed")
+	}
+	current.label = label
+}
+
+// Inspect describes a single node. It neither recomputes nor tracks anything,
+// so it is safe to call from anywhere, including inside getters and effects.
+func (rs *ReactiveSystem) Inspect(node SignalAware) NodeInfo {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	return rs.inspect(node.node())
+}
+
+// Graph describes every live node created since the system was created
+// WithIntrospection, in creation order. Without that option it returns nil.
+func (rs *ReactiveSystem) Graph() []NodeInfo {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	if !rs.trackNodes {
+		return nil
+	}
+
+	infos := make([]NodeInfo, 0, len(rs.nodes))
+	live := rs.nodes[:0]
+	for _, p := range rs.nodes {
+		if n := p.Value(); n != nil {
+			live = append(live, p)
+			infos = append(infos, rs.inspect(n))
+		}
+	}
+	clear(rs.nodes[len(live):])
+	rs.nodes = live
+	return infos
+}
+
+func (rs *ReactiveSystem) registerNode(n *signal) {
+	if rs.stats != nil {
+		rs.stats.addNode(n)
+	}
+	if !rs.trackNodes {
+		return
+	}
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	rs.nodeID(n)
+	rs.nodes = append(rs.nodes, weak.Make(n))
+}
+
+func (rs *ReactiveSystem) nodeID(n *signal) NodeID {
+	if n.id == 0 {
+		rs.lastNodeID++
+		n.id = rs.lastNodeID
+	}
+	return n.id
+}
+
+func (rs *ReactiveSystem) inspect(n *signal) NodeInfo {
+	flags := n.flags
+	info := NodeInfo{
+		ID:              rs.nodeID(n),
+		Label:           n.label,
+		Kind:            nodeKind(flags),
+		Dirty:           flags&fDirty != 0,
+		PendingComputed: flags&fPendingComputed != 0,
+		PendingEffect:   flags&fPendingEffect != 0,
+	}
+	for l := n.deps; l != nil; l = l.nextDep {
+		info.Deps = append(info.Deps, rs.nodeID(l.dep))
+	}
+	for l := n.subs; l != nil; l = l.nextSub {
+		info.Subs = append(info.Subs, rs.nodeID(l.sub))
+	}
+	return info
+}
+
+func nodeKind(flags subscriberFlags) NodeKind {
+	switch {
+	case flags&fEffectScope != 0:
+		return KindEffectScope
+	case flags&fEffect != 0:
+		return KindEffect
+	case flags&fComputed != 0:
+		return KindComputed
+	default:
+		return KindSignal
+	}
+}
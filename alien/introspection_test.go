@@ -0,0 +1,102 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectDepsAndSubs(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	count := alien.Signal(rs, 1)
+	count.SetLabel("count")
+	doubled := alien.Computed(rs, func(oldValue int) int {
+		return count.Value() * 2
+	})
+	doubled.SetLabel("doubled")
+	alien.Effect(rs, func() error {
+		alien.Label(rs, "logger")
+		doubled.Value()
+		return nil
+	})
+
+	countInfo := rs.Inspect(count)
+	doubledInfo := rs.Inspect(doubled)
+	assert.Equal(t, "count", countInfo.Label)
+	assert.Equal(t, alien.KindSignal, countInfo.Kind)
+	assert.Equal(t, []alien.NodeID{doubledInfo.ID}, countInfo.Subs)
+	assert.Empty(t, countInfo.Deps)
+
+	assert.Equal(t, alien.KindComputed, doubledInfo.Kind)
+	assert.Equal(t, []alien.NodeID{countInfo.ID}, doubledInfo.Deps)
+	require.Len(t, doubledInfo.Subs, 1)
+	assert.False(t, doubledInfo.Dirty)
+
+	// inspecting is stable and doesn't track
+	assert.Equal(t, countInfo, rs.Inspect(count))
+}
+
+func TestInspectDoesNotRecompute(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	count := alien.Signal(rs, 1)
+	runs := 0
+	doubled := alien.Computed(rs, func(oldValue int) int {
+		runs++
+		return count.Value() * 2
+	})
+	alien.Effect(rs, func() error {
+		rs.Inspect(doubled)
+		return nil
+	})
+
+	info := rs.Inspect(doubled)
+	assert.True(t, info.Dirty)
+	assert.Empty(t, info.Subs)
+	assert.Zero(t, runs)
+}
+
+func TestGraphDescribesWholeSystem(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithIntrospection())
+
+	a := alien.Signal(rs, 1)
+	b := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	alien.EffectScope(rs, func() error {
+		alien.Label(rs, "scope")
+		alien.Effect(rs, func() error {
+			b.Value()
+			return nil
+		})
+		return nil
+	})
+
+	graph := rs.Graph()
+	require.Len(t, graph, 4)
+	kinds := []alien.NodeKind{}
+	for _, n := range graph {
+		kinds = append(kinds, n.Kind)
+	}
+	assert.Equal(t, []alien.NodeKind{
+		alien.KindSignal,
+		alien.KindComputed,
+		alien.KindEffectScope,
+		alien.KindEffect,
+	}, kinds)
+	assert.Equal(t, "scope", graph[2].Name())
+	assert.Equal(t, "effect#4", graph[3].Name())
+	assert.Equal(t, []alien.NodeID{graph[3].ID}, graph[2].Deps)
+	assert.Equal(t, []alien.NodeID{graph[1].ID}, graph[3].Deps)
+
+	assert.Nil(t, alien.CreateReactiveSystem(nil).Graph())
+}
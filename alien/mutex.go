@@ -0,0 +1,55 @@
+package alien
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// reentrantMutex is a mutex the owning goroutine can lock again.
+//
+// Computed getters and effects call back into the ReactiveSystem while it is
+// already locked (reading other signals, creating inner effects, writing
+// signals), so a plain sync.Mutex would deadlock on the first nested call.
+type reentrantMutex struct {
+	mu    sync.Mutex
+	owner atomic.Int64
+	depth int
+}
+
+func (m *reentrantMutex) Lock() {
+	id := goroutineID()
+	if m.owner.Load() == id {
+		m.depth++
+		return
+	}
+	m.mu.Lock()
+	m.owner.Store(id)
+	m.depth = 1
+}
+
+func (m *reentrantMutex) Unlock() {
+	m.depth--
+	if m.depth == 0 {
+		m.owner.Store(0)
+		m.mu.Unlock()
+	}
+}
+
+// goroutineID parses the id out of the "goroutine 123 [running]:" header
+// that runtime.Stack writes for the calling goroutine.
+func goroutineID() int64 {
+	var buf [32]byte
+	n := runtime.Stack(buf[:], false)
+	const prefix = len("goroutine ")
+
+	var id int64
+	for i := prefix; i < n; i++ {
+		c := buf[i]
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + int64(c-'0')
+	}
+	return id
+}
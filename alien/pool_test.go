@@ -0,0 +1,183 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSteadyStatePropagationDoesNotAllocate(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	//     src
+	//    /   \
+	//   a     b
+	//    \   /
+	//     sum -> effect, effect
+	src := alien.Signal(rs, 0)
+	a := alien.Computed(rs, func(oldValue int) int {
+		return src.Value() + 1
+	})
+	b := alien.Computed(rs, func(oldValue int) int {
+		return src.Value() * 2
+	})
+	sum := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + b.Value()
+	})
+	last := 0
+	alien.Effect(rs, func() error {
+		last = sum.Value()
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		a.Value()
+		src.Value()
+		return nil
+	})
+
+	i := 0
+	allocs := testing.AllocsPerRun(1000, func() {
+		i++
+		src.SetValue(i)
+	})
+	assert.Zero(t, allocs)
+	assert.Equal(t, i+1+i*2, last)
+}
+
+func TestSteadyStateBatchDoesNotAllocate(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	signals := make([]*alien.WriteableSignal[int], 8)
+	for i := range signals {
+		signals[i] = alien.Signal(rs, 0)
+	}
+	alien.EffectScope(rs, func() error {
+		for _, s := range signals {
+			alien.Effect(rs, func() error {
+				s.Value()
+				return nil
+			})
+		}
+		return nil
+	})
+
+	i := 0
+	allocs := testing.AllocsPerRun(1000, func() {
+		i++
+		rs.StartBatch()
+		for _, s := range signals {
+			s.SetValue(i)
+		}
+		rs.EndBatch()
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestDynamicRetrackingDoesNotAllocate(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	toggle := alien.Signal(rs, false)
+	left := alien.Signal(rs, 1)
+	right := alien.Signal(rs, 2)
+	alien.Effect(rs, func() error {
+		if toggle.Value() {
+			left.Value()
+		} else {
+			right.Value()
+		}
+		return nil
+	})
+
+	// warm up the pools
+	toggle.SetValue(true)
+	toggle.SetValue(false)
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		toggle.SetValue(!toggle.Value())
+	})
+	assert.Zero(t, allocs)
+}
+
+func newDiamondEffect(rs *alien.ReactiveSystem) *alien.WriteableSignal[int] {
+	src := alien.Signal(rs, 0)
+	left := alien.Computed(rs, func(oldValue int) int {
+		return src.Value() + 1
+	})
+	right := alien.Computed(rs, func(oldValue int) int {
+		return src.Value() * 2
+	})
+	sum := alien.Computed(rs, func(oldValue int) int {
+		return left.Value() + right.Value()
+	})
+	alien.Effect(rs, func() error {
+		sum.Value()
+		return nil
+	})
+	return src
+}
+
+func TestDiamondEffectWriteDoesNotAllocate(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	src := newDiamondEffect(rs)
+
+	i := 0
+	allocs := testing.AllocsPerRun(1000, func() {
+		i++
+		src.SetValue(i)
+	})
+	assert.Zero(t, allocs)
+}
+
+// Guards the single-threaded hot path: a write through a diamond of computeds
+// into an effect.
+func BenchmarkDiamondEffectSetValue(b *testing.B) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		b.Fatal(err)
+	})
+	src := newDiamondEffect(rs)
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		src.SetValue(i + 1)
+	}
+}
+
+// The same with a wide diamond: ten computeds between the write and the
+// effect, which is where per-computed overhead shows up.
+func BenchmarkWideDiamondEffectSetValue(b *testing.B) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		b.Fatal(err)
+	})
+	src := alien.Signal(rs, 0)
+	branches := make([]*alien.ReadonlySignal[int], 9)
+	for i := range branches {
+		branches[i] = alien.Computed(rs, func(oldValue int) int {
+			return src.Value() + i
+		})
+	}
+	sum := alien.Computed(rs, func(oldValue int) int {
+		total := 0
+		for _, c := range branches {
+			total += c.Value()
+		}
+		return total
+	})
+	alien.Effect(rs, func() error {
+		sum.Value()
+		return nil
+	})
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		src.SetValue(i + 1)
+	}
+}
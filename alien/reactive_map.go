@@ -0,0 +1,196 @@
+package alien
+
+// ReactiveMap is a map whose reads are tracked per key.
+//
+// Get and Has only depend on the key they read, Len and Keys only on which
+// keys exist and Range on every entry.
+type ReactiveMap[K comparable, V any] struct {
+	signal   // notified when keys are added or removed
+	rs       *ReactiveSystem
+	values   map[K]V
+	keyNodes map[K]*signal
+	nodeKeys map[*signal]K
+	iter     *signal // notified by every write
+	equals   func(a, b V) bool
+}
+
+func (m *ReactiveMap[K, V]) isSignalAware() {}
+
+func NewReactiveMap[K comparable, V comparable](rs *ReactiveSystem, initial map[K]V) *ReactiveMap[K, V] {
+	return NewReactiveMapFunc(rs, initial, Equal[V])
+}
+
+// NewReactiveMapFunc creates a map for any value type, using equals to decide
+// whether Set changed a value. A nil equals behaves like NeverEqual.
+func NewReactiveMapFunc[K comparable, V any](rs *ReactiveSystem, initial map[K]V, equals func(a, b V) bool) *ReactiveMap[K, V] {
+	if equals == nil {
+		equals = NeverEqual[V]
+	}
+	m := &ReactiveMap[K, V]{
+		rs:       rs,
+		values:   make(map[K]V, len(initial)),
+		keyNodes: map[K]*signal{},
+		nodeKeys: map[*signal]K{},
+		equals:   equals,
+	}
+	for k, v := range initial {
+		m.values[k] = v
+	}
+	m.signal.ref = m
+	rs.registerNode(&m.signal)
+	m.iter = rs.collectionNode(m)
+	return m
+}
+
+func (m *ReactiveMap[K, V]) keyNode(key K) *signal {
+	n, ok := m.keyNodes[key]
+	if !ok {
+		n = m.rs.collectionNode(m)
+		m.keyNodes[key] = n
+		m.nodeKeys[n] = key
+	}
+	return n
+}
+
+// Returns the node of key for a write, forgetting it first if nothing reads
+// it any more. Triggering a forgotten node still marks the computeds that
+// detached while holding it as changed.
+func (m *ReactiveMap[K, V]) writeNode(key K) *signal {
+	n := m.keyNodes[key]
+	if n != nil && n.subs == nil {
+		delete(m.keyNodes, key)
+		delete(m.nodeKeys, n)
+	}
+	return n
+}
+
+// Forgets the node of a key once its last reader stopped tracking it, so
+// probing keys that never get written doesn't grow the map's nodes.
+func (m *ReactiveMap[K, V]) releaseNode(n *signal) {
+	key, ok := m.nodeKeys[n]
+	if !ok {
+		return
+	}
+	m.rs.triggerNode(m.writeNode(key))
+}
+
+func (m *ReactiveMap[K, V]) trackKey(key K) {
+	if m.rs.activeSub != nil {
+		m.rs.trackNode(m.keyNode(key))
+	}
+}
+
+// Get returns the value stored under key, tracking only that key.
+func (m *ReactiveMap[K, V]) Get(key K) (V, bool) {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	m.trackKey(key)
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Has reports whether key is present, tracking only that key.
+func (m *ReactiveMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Len returns the number of entries, tracking only additions and removals.
+func (m *ReactiveMap[K, V]) Len() int {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	m.rs.trackNode(&m.signal)
+	return len(m.values)
+}
+
+// Keys returns the keys in unspecified order, tracking only additions and
+// removals.
+func (m *ReactiveMap[K, V]) Keys() []K {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	m.rs.trackNode(&m.signal)
+	keys := make([]K, 0, len(m.values))
+	for k := range m.values {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Range calls fn for every entry in unspecified order until fn returns false.
+// It depends on every entry, any write re-runs the reader.
+func (m *ReactiveMap[K, V]) Range(fn func(key K, value V) bool) {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	m.rs.trackNode(m.iter)
+	for k, v := range m.values {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// Set stores value under key, notifying readers of that key and, if the key is
+// new, readers of Len and Keys.
+func (m *ReactiveMap[K, V]) Set(key K, value V) {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	old, ok := m.values[key]
+	if ok && m.equals(old, value) {
+		return
+	}
+	m.values[key] = value
+
+	m.rs.triggerNode(m.writeNode(key))
+	if !ok {
+		m.rs.triggerNode(&m.signal)
+	}
+	m.rs.triggerNode(m.iter)
+	m.rs.flushWrite()
+}
+
+// Delete removes key and reports whether it was present.
+func (m *ReactiveMap[K, V]) Delete(key K) bool {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	if _, ok := m.values[key]; !ok {
+		return false
+	}
+	delete(m.values, key)
+
+	m.rs.triggerNode(m.writeNode(key))
+	m.rs.triggerNode(&m.signal)
+	m.rs.triggerNode(m.iter)
+	m.rs.flushWrite()
+	return true
+}
+
+// Clear removes every entry.
+func (m *ReactiveMap[K, V]) Clear() {
+	if m.rs.mu != nil {
+		m.rs.mu.Lock()
+		defer m.rs.mu.Unlock()
+	}
+	if len(m.values) == 0 {
+		return
+	}
+	for k := range m.values {
+		m.rs.triggerNode(m.writeNode(k))
+	}
+	clear(m.values)
+
+	m.rs.triggerNode(&m.signal)
+	m.rs.triggerNode(m.iter)
+	m.rs.flushWrite()
+}
@@ -0,0 +1,143 @@
+package alien_test
+
+import (
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReactiveMapTracksPerKey(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	m := alien.NewReactiveMap(rs, map[string]int{"a": 1, "b": 2})
+
+	aRuns, cRuns, lenRuns, rangeRuns := 0, 0, 0, 0
+	var a, sum, size int
+	var hasC bool
+	alien.Effect(rs, func() error {
+		aRuns++
+		a, _ = m.Get("a")
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		cRuns++
+		hasC = m.Has("c")
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		lenRuns++
+		size = m.Len()
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		rangeRuns++
+		sum = 0
+		m.Range(func(key string, value int) bool {
+			sum += value
+			return true
+		})
+		return nil
+	})
+
+	m.Set("b", 20)
+	assert.Equal(t, []int{1, 1, 1, 2}, []int{aRuns, cRuns, lenRuns, rangeRuns})
+	assert.Equal(t, 21, sum)
+
+	m.Set("a", 1)
+	assert.Equal(t, []int{1, 1, 1, 2}, []int{aRuns, cRuns, lenRuns, rangeRuns}, "equal writes are ignored")
+
+	m.Set("c", 3)
+	assert.Equal(t, []int{1, 2, 2, 3}, []int{aRuns, cRuns, lenRuns, rangeRuns})
+	assert.True(t, hasC)
+	assert.Equal(t, 3, size)
+
+	m.Set("a", 10)
+	assert.Equal(t, []int{2, 2, 2, 4}, []int{aRuns, cRuns, lenRuns, rangeRuns})
+	assert.Equal(t, 10, a)
+
+	assert.True(t, m.Delete("c"))
+	assert.False(t, m.Delete("c"))
+	assert.Equal(t, []int{2, 3, 3, 5}, []int{aRuns, cRuns, lenRuns, rangeRuns})
+	assert.False(t, hasC)
+	assert.Equal(t, 2, size)
+	assert.Equal(t, 30, sum)
+
+	keys := m.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	m.Clear()
+	assert.Equal(t, []int{3, 3, 4, 6}, []int{aRuns, cRuns, lenRuns, rangeRuns})
+	assert.Equal(t, 0, a)
+	assert.Equal(t, 0, size)
+}
+
+func TestReactiveMapInBatch(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	m := alien.NewReactiveMapFunc(rs, map[string][]int{}, alien.DeepEqual[[]int])
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		runs++
+		m.Len()
+		m.Get("x")
+		return nil
+	})
+
+	rs.Batch(func() {
+		m.Set("x", []int{1})
+		m.Set("y", []int{2})
+	})
+	assert.Equal(t, 2, runs)
+
+	m.Set("x", []int{1})
+	assert.Equal(t, 2, runs)
+}
+
+func TestReactiveMapForgetsUnreadKeys(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithIntrospection())
+	m := alien.NewReactiveMap(rs, map[int]int{})
+	nodes := len(rs.Graph())
+
+	stop := alien.Effect(rs, func() error {
+		for k := range 100 {
+			m.Has(k)
+		}
+		return nil
+	})
+	assert.Len(t, rs.Graph(), nodes+101)
+
+	stop()
+	runtime.GC()
+	assert.Len(t, rs.Graph(), nodes+1, "only the effect is left")
+	runtime.KeepAlive(stop)
+}
+
+func TestReactiveMapForgottenKeyStillReachesDetachedReaders(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	m := alien.NewReactiveMap(rs, map[string]int{})
+	x := alien.Computed(rs, func(oldValue int) int {
+		v, _ := m.Get("x")
+		return v
+	})
+
+	stop := alien.Effect(rs, func() error {
+		m.Get("x")
+		return nil
+	})
+	assert.Zero(t, x.Value(), "x detaches, the effect keeps the key node")
+
+	stop()
+	m.Set("x", 1)
+	assert.Equal(t, 1, x.Value())
+}
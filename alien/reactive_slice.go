@@ -0,0 +1,226 @@
+package alien
+
+// ReactiveSlice is a slice whose reads are tracked per index.
+//
+// Get only depends on the index it read, Len only on the length and Range
+// and Slice on every element.
+type ReactiveSlice[T any] struct {
+	signal // notified when the length changes
+	rs     *ReactiveSystem
+	items  []T
+	nodes  []*signal // per index, nil until read
+	iter   *signal   // notified by every write
+	equals func(a, b T) bool
+
+	listeners []*sliceListener[T]
+}
+
+// sliceListener receives the elements removed and added by every write, see
+// Reduce.
+type sliceListener[T any] struct {
+	fn func(removed, added []T)
+}
+
+func (s *ReactiveSlice[T]) isSignalAware() {}
+
+func NewReactiveSlice[T comparable](rs *ReactiveSystem, initial []T) *ReactiveSlice[T] {
+	return NewReactiveSliceFunc(rs, initial, Equal[T])
+}
+
+// NewReactiveSliceFunc creates a slice for any element type, using equals to
+// decide whether a write changed an element. A nil equals behaves like
+// NeverEqual.
+func NewReactiveSliceFunc[T any](rs *ReactiveSystem, initial []T, equals func(a, b T) bool) *ReactiveSlice[T] {
+	if equals == nil {
+		equals = NeverEqual[T]
+	}
+	s := &ReactiveSlice[T]{
+		rs:     rs,
+		items:  append([]T(nil), initial...),
+		equals: equals,
+	}
+	s.signal.ref = s
+	rs.registerNode(&s.signal)
+	s.iter = rs.collectionNode(s)
+	return s
+}
+
+func (s *ReactiveSlice[T]) trackIndex(i int) {
+	if s.rs.activeSub == nil {
+		return
+	}
+	if i >= len(s.nodes) {
+		s.nodes = append(s.nodes, make([]*signal, i+1-len(s.nodes))...)
+	}
+	n := s.nodes[i]
+	if n == nil {
+		n = s.rs.collectionNode(s)
+		s.nodes[i] = n
+	}
+	s.rs.trackNode(n)
+}
+
+func (s *ReactiveSlice[T]) triggerIndex(i int) {
+	if i < len(s.nodes) {
+		s.rs.triggerNode(s.nodes[i])
+	}
+}
+
+// Get returns the element at index i, tracking only that index. Like indexing
+// a slice it panics if i is out of range.
+func (s *ReactiveSlice[T]) Get(i int) T {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	v := s.items[i]
+	s.trackIndex(i)
+	return v
+}
+
+// Len returns the length, tracking only changes to it.
+func (s *ReactiveSlice[T]) Len() int {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	s.rs.trackNode(&s.signal)
+	return len(s.items)
+}
+
+// Range calls fn for every element in order until fn returns false. It
+// depends on every element, any write re-runs the reader.
+func (s *ReactiveSlice[T]) Range(fn func(i int, v T) bool) {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	s.rs.trackNode(s.iter)
+	for i, v := range s.items {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+// Slice returns a copy of the elements, depending on every element.
+func (s *ReactiveSlice[T]) Slice() []T {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	s.rs.trackNode(s.iter)
+	return append([]T(nil), s.items...)
+}
+
+// Set replaces the element at index i, notifying readers of that index.
+func (s *ReactiveSlice[T]) Set(i int, v T) {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	if s.equals(s.items[i], v) {
+		return
+	}
+	old := s.items[i]
+	s.items[i] = v
+
+	s.triggerIndex(i)
+	s.rs.triggerNode(s.iter)
+	s.emit([]T{old}, []T{v})
+	s.rs.flushWrite()
+}
+
+// Append adds elements to the end, notifying readers of the length.
+func (s *ReactiveSlice[T]) Append(values ...T) {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	if len(values) == 0 {
+		return
+	}
+	s.items = append(s.items, values...)
+
+	s.rs.triggerNode(&s.signal)
+	s.rs.triggerNode(s.iter)
+	s.emit(nil, values)
+	s.rs.flushWrite()
+}
+
+// Splice removes deleteCount elements starting at start, inserts values in
+// their place and returns the removed elements.
+//
+// Only readers of indexes whose element actually changed are notified, so
+// replacing an element with an equal one or splicing at the end leaves
+// readers of earlier indexes alone.
+func (s *ReactiveSlice[T]) Splice(start, deleteCount int, values ...T) []T {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	old := s.items
+	removed := append([]T(nil), old[start:start+deleteCount]...)
+
+	items := make([]T, 0, len(old)-deleteCount+len(values))
+	items = append(items, old[:start]...)
+	items = append(items, values...)
+	items = append(items, old[start+deleteCount:]...)
+
+	changed := false
+	for i := start; i < max(len(old), len(items)); i++ {
+		if i < len(old) && i < len(items) && s.equals(old[i], items[i]) {
+			continue
+		}
+		changed = true
+		s.triggerIndex(i)
+	}
+	if len(items) < len(s.nodes) {
+		clear(s.nodes[len(items):])
+		s.nodes = s.nodes[:len(items)]
+	}
+	s.items = items
+
+	if len(old) != len(items) {
+		s.rs.triggerNode(&s.signal)
+	}
+	if changed {
+		s.rs.triggerNode(s.iter)
+	}
+	if len(removed) > 0 || len(values) > 0 {
+		s.emit(removed, values)
+	}
+	s.rs.flushWrite()
+	return removed
+}
+
+func (s *ReactiveSlice[T]) listen(fn func(removed, added []T)) *sliceListener[T] {
+	l := &sliceListener[T]{fn: fn}
+	s.listeners = append(s.listeners, l)
+	return l
+}
+
+func (s *ReactiveSlice[T]) unlisten(l *sliceListener[T]) {
+	for i, other := range s.listeners {
+		if other == l {
+			s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// Hands a write to the listeners. Their own writes are batched with the
+// write that triggered them.
+//
+// @param removed - The elements that left the slice.
+// @param added - The elements that entered the slice.
+func (s *ReactiveSlice[T]) emit(removed, added []T) {
+	if len(s.listeners) == 0 {
+		return
+	}
+	s.rs.batchDepth++
+	for _, l := range s.listeners {
+		l.fn(removed, added)
+	}
+	s.rs.batchDepth--
+}
@@ -0,0 +1,89 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReactiveSliceTracksPerIndex(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	s := alien.NewReactiveSlice(rs, []string{"a", "b", "c"})
+
+	firstRuns, lastRuns, lenRuns, sliceRuns := 0, 0, 0, 0
+	var first, last string
+	var all []string
+	alien.Effect(rs, func() error {
+		firstRuns++
+		first = s.Get(0)
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		lastRuns++
+		last = s.Get(2)
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		lenRuns++
+		s.Len()
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		sliceRuns++
+		all = s.Slice()
+		return nil
+	})
+
+	s.Set(1, "B")
+	assert.Equal(t, []int{1, 1, 1, 2}, []int{firstRuns, lastRuns, lenRuns, sliceRuns})
+
+	s.Append("d", "e")
+	assert.Equal(t, []int{1, 1, 2, 3}, []int{firstRuns, lastRuns, lenRuns, sliceRuns})
+	assert.Equal(t, []string{"a", "B", "c", "d", "e"}, all)
+
+	removed := s.Splice(1, 1, "x")
+	assert.Equal(t, []string{"B"}, removed)
+	assert.Equal(t, []int{1, 1, 2, 4}, []int{firstRuns, lastRuns, lenRuns, sliceRuns}, "same length, index 2 unchanged")
+
+	removed = s.Splice(0, 1)
+	assert.Equal(t, []string{"a"}, removed)
+	assert.Equal(t, []int{2, 2, 3, 5}, []int{firstRuns, lastRuns, lenRuns, sliceRuns})
+	assert.Equal(t, "x", first)
+	assert.Equal(t, "d", last)
+	assert.Equal(t, []string{"x", "c", "d", "e"}, all)
+
+	s.Splice(4, 0, "f")
+	assert.Equal(t, []int{2, 2, 4, 6}, []int{firstRuns, lastRuns, lenRuns, sliceRuns}, "appending via Splice leaves earlier indexes alone")
+}
+
+func TestReactiveSliceShrinkNotifiesRemovedIndexes(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	s := alien.NewReactiveSlice(rs, []int{1, 2, 3})
+
+	var third int
+	ok := true
+	alien.Effect(rs, func() error {
+		if s.Len() < 3 {
+			ok = false
+			return nil
+		}
+		ok = true
+		third = s.Get(2)
+		return nil
+	})
+
+	s.Splice(1, 2)
+	assert.False(t, ok)
+
+	s.Append(5, 6)
+	assert.True(t, ok)
+	assert.Equal(t, 6, third)
+
+	s.Set(2, 7)
+	assert.Equal(t, 7, third)
+}
@@ -1,5 +1,10 @@
 package alien
 
+import (
+	"context"
+	"weak"
+)
+
 type OnErrorFunc func(from SignalAware, err error)
 
 type ReactiveSystem struct {
@@ -7,14 +12,77 @@ type ReactiveSystem struct {
 	activeSub         *signal
 	queuedEffects     *OneWayLink_signal
 	queuedEffectsTail *OneWayLink_signal
+	queuedPreTail     *OneWayLink_signal
+	postEffects       *OneWayLink_signal
+	postEffectsTail   *OneWayLink_signal
+
+	// free lists so steady-state propagation doesn't allocate
+	linkPool        *link
+	effectQueuePool *OneWayLink_signal
+	linkStackPool   *OneWayLink_link
 
 	activeScope *signal
 	onError     OnErrorFunc
 	pauseStack  []*signal
+
+	// set while tracing, stats, recording, a transaction, panic recovery, a
+	// scheduler or a flush is on, so hot paths skip them with a single check
+	hooked bool
+
+	mu            *reentrantMutex
+	recoverPanics bool
+	scheduler     Scheduler
+	tracer        Tracer
+	clock         Clock
+
+	lastNodeID NodeID
+	trackNodes bool
+	nodes      []weak.Pointer[signal]
+
+	keyed     map[string]keyedSignal
+	recorders []writeRecorder
+
+	maxEffectRuns   int
+	flushDepth      int
+	effectDepth     int
+	flushID         uint64
+	flushReported   bool
+	settlingEffects []*signal
+
+	cycleNode    *signal
+	cycle        *CycleError
+	cycleTargets []*signal
+	cyclePath    []*signal
+	cycleTail    []*signal
+	cycleHits    uint64
+	lastCycle    *CycleError
+
+	writeEpoch uint64
+
+	stats *stats
+
+	ctx context.Context
+
+	transactions []*transaction
+}
+
+type Option func(rs *ReactiveSystem)
+
+// WithConcurrency makes the ReactiveSystem safe to use from many goroutines.
+//
+// Every public entry point (reading or writing a signal, creating or stopping
+// an effect, batching) takes a system-wide lock that the owning goroutine may
+// re-enter, so getters and effects can freely call back into the system.
+// Without this option the system does no locking at all.
+func WithConcurrency() Option {
+	return func(rs *ReactiveSystem) {
+		rs.mu = &reentrantMutex{}
+	}
 }
 
 type SignalAware interface {
 	isSignalAware()
+	node() *signal
 }
 
 type OneWayLink_signal struct {
@@ -27,20 +95,64 @@ type OneWayLink_link struct {
 	linked *OneWayLink_link
 }
 
-func CreateReactiveSystem(onError OnErrorFunc) *ReactiveSystem {
-	rs := &ReactiveSystem{onError: onError}
+func CreateReactiveSystem(onError OnErrorFunc, opts ...Option) *ReactiveSystem {
+	rs := &ReactiveSystem{
+		onError:       onError,
+		clock:         RealClock{},
+		ctx:           context.Background(),
+		maxEffectRuns: DefaultConvergenceLimit,
+	}
+	for _, opt := range opts {
+		opt(rs)
+	}
+	rs.updateHooked()
 
 	return rs
 }
 
+// Recomputes rs.hooked, must be called whenever one of the features it covers
+// is switched on or off.
+func (rs *ReactiveSystem) updateHooked() {
+	rs.hooked = rs.tracer != nil ||
+		rs.stats != nil ||
+		rs.recorders != nil ||
+		rs.transactions != nil ||
+		rs.recoverPanics ||
+		rs.scheduler != nil ||
+		rs.flushDepth > 0
+}
+
+// StartBatch defers effect notifications until the matching EndBatch.
+//
+// In concurrent mode the calling goroutine holds the system lock from
+// StartBatch until the matching EndBatch, so other goroutines can't interleave
+// writes into the batch. Both calls must happen on the same goroutine.
 func (rs *ReactiveSystem) StartBatch() {
+	if rs.mu != nil {
+		rs.mu.Lock()
+	}
 	rs.batchDepth++
+	if rs.tracer != nil {
+		rs.tracer.BatchStart(rs.batchDepth)
+	}
+	if rs.batchDepth == 1 && rs.recorders != nil {
+		rs.recordBatchStart()
+	}
 }
 
 func (rs *ReactiveSystem) EndBatch() {
+	if rs.mu != nil {
+		defer rs.mu.Unlock()
+	}
+	if rs.tracer != nil {
+		rs.tracer.BatchEnd(rs.batchDepth)
+	}
 	rs.batchDepth--
 	if rs.batchDepth == 0 {
 		rs.processEffectNotifications()
+		if rs.recorders != nil {
+			rs.recordBatchEnd()
+		}
 	}
 }
 
@@ -51,11 +163,19 @@ func (rs *ReactiveSystem) Batch(cb func()) {
 }
 
 func (rs *ReactiveSystem) PauseTracking() {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
 	rs.pauseStack = append(rs.pauseStack, rs.activeSub)
 	rs.activeSub = nil
 }
 
 func (rs *ReactiveSystem) ResumeTracking() {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
 	lastIdx := len(rs.pauseStack) - 1
 	rs.activeSub = rs.pauseStack[lastIdx]
 	rs.pauseStack = rs.pauseStack[:lastIdx]
@@ -89,6 +209,7 @@ func (rs *ReactiveSystem) updateDirtyFlag(sub *signal, flags subscriberFlags) bo
 func (rs *ReactiveSystem) checkDirty(current *link) bool {
 	prevLinks := (*OneWayLink_link)(nil)
 	checkDepth := 0
+	hits := rs.cycleHits
 
 top:
 	for {
@@ -103,13 +224,19 @@ top:
 				for checkDepth != 0 {
 					checkDepth--
 					computed := current.sub
+					computed.flags &^= fChecking
 					firstSub := computed.subs
 
-					if updateComputed(rs, current.sub) {
+					var changed bool
+					if rs.cycleHits != hits && rs.onCycle(computed) {
+						changed = rs.failCycle(computed)
+					} else {
+						changed = updateComputed(rs, computed)
+					}
+					if changed {
 						if firstSub.nextSub != nil {
 							rs.shallowPropagate(firstSub)
-							current = prevLinks.target
-							prevLinks = prevLinks.linked
+							current, prevLinks = rs.popLink(prevLinks)
 						} else {
 							current = firstSub
 						}
@@ -117,30 +244,40 @@ top:
 					}
 
 					if firstSub.nextSub != nil {
-						if current = prevLinks.target.nextDep; current == nil {
+						var prev *link
+						prev, prevLinks = rs.popLink(prevLinks)
+						if current = prev.nextDep; current == nil {
+							rs.endChecks(prev, prevLinks, checkDepth)
 							return false
 						}
-						prevLinks = prevLinks.linked
 						continue top
 					}
 
+					rs.endChecks(firstSub, prevLinks, checkDepth)
 					return false
 				}
+				rs.releaseLinkStack(prevLinks)
 				return true
 			}
 		} else if depFlags&(fComputed|fPendingComputed) == fComputed|fPendingComputed {
-			dep.flags = depFlags & ^fPendingComputed
-			if current.nextSub != nil && current.prevSub != nil {
-				prevLinks = &OneWayLink_link{target: current, linked: prevLinks}
+			dep.flags = depFlags&^fPendingComputed | fChecking
+			if current.nextSub != nil || current.prevSub != nil {
+				prevLinks = rs.pushLink(current, prevLinks)
 			}
 			checkDepth++
 			current = dep.deps
 			continue
+		} else if depFlags&(fComputed|fTracking) == fComputed|fTracking {
+			// dep is being computed further up the stack, see reportCycle
+			rs.cycleNode = dep
 		}
 
-		if current = current.nextDep; current == nil {
-			return false
+		if next := current.nextDep; next != nil {
+			current = next
+			continue
 		}
+		rs.endChecks(current, prevLinks, checkDepth)
+		return false
 	}
 }
 
@@ -215,10 +352,21 @@ func (rs *ReactiveSystem) isValidLink(checkLink *link, sub *signal) bool {
 // @param depsTail - The current tail link in the subscriber's chain.
 // @returns The newly created link object.
 func (rs *ReactiveSystem) linkNewDep(dep *signal, sub *signal, nextDep, depsTail *link) *link {
-	newLink := &link{
-		dep:     dep,
-		sub:     sub,
-		nextDep: nextDep,
+	if rs.stats != nil {
+		rs.stats.Links++
+	}
+	newLink := rs.linkPool
+	if newLink != nil {
+		rs.linkPool = newLink.nextDep
+		newLink.dep = dep
+		newLink.sub = sub
+		newLink.nextDep = nextDep
+	} else {
+		newLink = &link{
+			dep:     dep,
+			sub:     sub,
+			nextDep: nextDep,
+		}
 	}
 
 	if depsTail == nil {
@@ -238,6 +386,10 @@ func (rs *ReactiveSystem) linkNewDep(dep *signal, sub *signal, nextDep, depsTail
 	sub.depsTail = newLink
 	dep.subsTail = newLink
 
+	if rs.tracer != nil {
+		rs.tracer.Link(rs.traceNode(dep), rs.traceNode(sub))
+	}
+
 	return newLink
 }
 
@@ -253,6 +405,8 @@ func (rs *ReactiveSystem) propagate(current *link) {
 	branchs := (*OneWayLink_link)(nil)
 	branchDepth := 0
 	targetFlag := fDirty
+	// levels below the written node, of current, of next and the deepest one
+	depth, nextDepth, maxDepth := uint64(1), uint64(1), uint64(1)
 
 top:
 	for {
@@ -275,10 +429,16 @@ top:
 			subSubs := sub.subs
 			if subSubs != nil {
 				current = subSubs
+				depth++
+				maxDepth = max(maxDepth, depth)
 				if subSubs.nextSub != nil {
-					branchs = &OneWayLink_link{target: next, linked: branchs}
+					branchs = rs.pushLink(next, branchs)
+					if rs.stats != nil {
+						rs.stats.depths = append(rs.stats.depths, nextDepth)
+					}
 					branchDepth++
 					next = current.nextSub
+					nextDepth = depth
 					targetFlag = fPendingComputed
 				} else {
 					if flags&fEffect != 0 {
@@ -290,24 +450,12 @@ top:
 				continue
 			}
 			if flags&fEffect != 0 {
-				if rs.queuedEffectsTail != nil {
-					rs.queuedEffectsTail.linked = &OneWayLink_signal{target: sub}
-					rs.queuedEffectsTail = rs.queuedEffectsTail.linked
-				} else {
-					rs.queuedEffects = &OneWayLink_signal{target: sub}
-					rs.queuedEffectsTail = rs.queuedEffects
-				}
+				rs.queueEffect(sub)
 			}
 		} else if flags&(fTracking|targetFlag) == 0 {
 			sub.flags = flags | targetFlag | fNotified
 			if flags&(fEffect|fNotified) == fEffect {
-				if rs.queuedEffectsTail != nil {
-					rs.queuedEffectsTail.linked = &OneWayLink_signal{target: sub}
-					rs.queuedEffectsTail = rs.queuedEffectsTail.linked
-				} else {
-					rs.queuedEffects = &OneWayLink_signal{target: sub}
-					rs.queuedEffectsTail = rs.queuedEffects
-				}
+				rs.queueEffect(sub)
 			}
 		} else if flags&targetFlag == 0 &&
 			flags&fPropagated != 0 &&
@@ -317,6 +465,7 @@ top:
 
 		if current = next; current != nil {
 			next = current.nextSub
+			depth = nextDepth
 			if branchDepth != 0 {
 				targetFlag = fPendingComputed
 			} else {
@@ -327,9 +476,14 @@ top:
 
 		for branchDepth != 0 {
 			branchDepth--
-			current = branchs.target
-			branchs = branchs.linked
+			current, branchs = rs.popLink(branchs)
+			if rs.stats != nil {
+				depths := rs.stats.depths
+				nextDepth = depths[len(depths)-1]
+				rs.stats.depths = depths[:len(depths)-1]
+			}
 			if current != nil {
+				depth = nextDepth
 				next = current.nextSub
 				if branchDepth != 0 {
 					targetFlag = fPendingComputed
@@ -341,6 +495,9 @@ top:
 		}
 		break
 	}
+	if rs.stats != nil {
+		rs.stats.PropagationDepth.Observe(maxDepth)
+	}
 }
 
 // Quickly propagates PendingComputed status to Dirty for each subscriber in the chain.
@@ -357,13 +514,7 @@ func (rs *ReactiveSystem) shallowPropagate(link *link) {
 		if justPendingDirty == fPendingComputed {
 			sub.flags = subFlags | fDirty | fNotified
 			if subFlags&(fEffect|fNotified) == fEffect {
-				if rs.queuedEffectsTail != nil {
-					rs.queuedEffectsTail.linked = &OneWayLink_signal{target: sub}
-					rs.queuedEffectsTail = rs.queuedEffectsTail.linked
-				} else {
-					rs.queuedEffects = &OneWayLink_signal{target: sub}
-					rs.queuedEffectsTail = rs.queuedEffects
-				}
+				rs.queueEffect(sub)
 			}
 		}
 		link = link.nextSub
@@ -435,13 +586,22 @@ func (rs *ReactiveSystem) clearTracking(link *link) {
 		} else {
 			dep.subs = nextSub
 		}
+		if rs.tracer != nil {
+			rs.tracer.Unlink(rs.traceNode(dep), rs.traceNode(link.sub))
+		}
+		rs.releaseLink(link)
 
 		subs := dep.subs
 		flags := dep.flags
-		if subs == nil && flags != 0 {
+		if subs == nil && flags == 0 {
+			if owner, ok := dep.ref.(nodeOwner); ok {
+				owner.releaseNode(dep)
+			}
+		} else if subs == nil {
 			if flags&fDirty == 0 {
 				dep.flags = flags | fDirty
 			}
+			rs.runCleanups(dep)
 
 			depDeps := dep.deps
 			if depDeps != nil {
@@ -460,3 +620,129 @@ func (rs *ReactiveSystem) clearTracking(link *link) {
 		}
 	}
 }
+
+// Returns a detached link to linkPool so linkNewDep can reuse it.
+//
+// @param l - A link that is no longer part of any deps or subs list.
+func (rs *ReactiveSystem) releaseLink(l *link) {
+	if rs.stats != nil {
+		rs.stats.Links--
+	}
+	*l = link{nextDep: rs.linkPool}
+	rs.linkPool = l
+}
+
+// Appends a subscriber to the queuedEffects list, reusing a pooled node if possible.
+//
+// @param sub - The effect or effect scope to queue.
+func (rs *ReactiveSystem) queueEffect(sub *signal) {
+	node := rs.effectQueuePool
+	if node != nil {
+		rs.effectQueuePool = node.linked
+		node.target = sub
+		node.linked = nil
+	} else {
+		node = &OneWayLink_signal{target: sub}
+	}
+
+	switch {
+	case sub.flags&fFlushPre != 0:
+		// pre-flush effects run ahead of everything else, in queue order
+		if rs.queuedPreTail != nil {
+			node.linked = rs.queuedPreTail.linked
+			rs.queuedPreTail.linked = node
+		} else {
+			node.linked = rs.queuedEffects
+			rs.queuedEffects = node
+		}
+		if node.linked == nil {
+			rs.queuedEffectsTail = node
+		}
+		rs.queuedPreTail = node
+	case sub.flags&fFlushPost != 0:
+		if rs.postEffectsTail != nil {
+			rs.postEffectsTail.linked = node
+		} else {
+			rs.postEffects = node
+		}
+		rs.postEffectsTail = node
+	default:
+		if rs.queuedEffectsTail != nil {
+			rs.queuedEffectsTail.linked = node
+		} else {
+			rs.queuedEffects = node
+		}
+		rs.queuedEffectsTail = node
+	}
+}
+
+// Reports whether any effects are waiting to run.
+//
+// Once the queuedEffects list drains, post-flush effects are moved onto it so
+// they run after every other effect of the same flush.
+//
+// @returns true if queuedEffects is not empty.
+func (rs *ReactiveSystem) hasQueuedEffects() bool {
+	if rs.queuedEffects == nil && rs.postEffects != nil {
+		rs.queuedEffects, rs.queuedEffectsTail = rs.postEffects, rs.postEffectsTail
+		rs.postEffects, rs.postEffectsTail = nil, nil
+	}
+	return rs.queuedEffects != nil
+}
+
+// Removes the head of the queuedEffects list and returns its node to the pool.
+//
+// @returns The effect or effect scope that was at the head of the queue.
+func (rs *ReactiveSystem) dequeueEffect() *signal {
+	node := rs.queuedEffects
+	rs.queuedEffects = node.linked
+	if rs.queuedEffects == nil {
+		rs.queuedEffectsTail = nil
+	}
+	if node == rs.queuedPreTail {
+		rs.queuedPreTail = nil
+	}
+
+	target := node.target
+	node.target = nil
+	node.linked = rs.effectQueuePool
+	rs.effectQueuePool = node
+	return target
+}
+
+// Pushes a link onto a traversal stack, reusing a pooled node if possible.
+//
+// @param target - The link to remember.
+// @param linked - The current top of the stack.
+// @returns The new top of the stack.
+func (rs *ReactiveSystem) pushLink(target *link, linked *OneWayLink_link) *OneWayLink_link {
+	node := rs.linkStackPool
+	if node != nil {
+		rs.linkStackPool = node.linked
+		node.target = target
+		node.linked = linked
+		return node
+	}
+	return &OneWayLink_link{target: target, linked: linked}
+}
+
+// Pops a traversal stack, returning the node to the pool.
+//
+// @param node - The current top of the stack.
+// @returns The remembered link and the rest of the stack.
+func (rs *ReactiveSystem) popLink(node *OneWayLink_link) (*link, *OneWayLink_link) {
+	target, linked := node.target, node.linked
+	node.target = nil
+	node.linked = rs.linkStackPool
+	rs.linkStackPool = node
+	return target, linked
+}
+
+// Returns every node of an abandoned traversal stack to the pool.
+//
+// @param node - The top of the stack, may be nil.
+func (rs *ReactiveSystem) releaseLinkStack(node *OneWayLink_link) {
+	for node != nil {
+		_, node = rs.popLink(node)
+	}
+}
@@ -0,0 +1,56 @@
+package alien
+
+// writeRecorder observes every signal write and the outermost batch
+// boundaries, see History.
+//
+// A write made outside of any batch is reported as if it had its own batch,
+// which also covers the writes made by the effects it triggers.
+type writeRecorder interface {
+	recordWrite(target writeTarget, oldValue, newValue any)
+	batchStarted()
+	batchEnded()
+}
+
+// writeTarget is a signal whose value can be written back without knowing
+// its type.
+type writeTarget interface {
+	node() *signal
+	restore(v any)
+	same(a, b any) bool
+}
+
+func (rs *ReactiveSystem) addRecorder(r writeRecorder) {
+	rs.recorders = append(rs.recorders, r)
+	rs.updateHooked()
+}
+
+func (rs *ReactiveSystem) removeRecorder(r writeRecorder) {
+	for i, other := range rs.recorders {
+		if other == r {
+			rs.recorders = append(rs.recorders[:i], rs.recorders[i+1:]...)
+			break
+		}
+	}
+	if len(rs.recorders) == 0 {
+		rs.recorders = nil
+	}
+	rs.updateHooked()
+}
+
+func (rs *ReactiveSystem) recordWrite(target writeTarget, oldValue, newValue any) {
+	for _, r := range rs.recorders {
+		r.recordWrite(target, oldValue, newValue)
+	}
+}
+
+func (rs *ReactiveSystem) recordBatchStart() {
+	for _, r := range rs.recorders {
+		r.batchStarted()
+	}
+}
+
+func (rs *ReactiveSystem) recordBatchEnd() {
+	for _, r := range rs.recorders {
+		r.batchEnded()
+	}
+}
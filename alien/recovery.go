@@ -0,0 +1,88 @@
+package alien
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// WithPanicRecovery makes the ReactiveSystem recover panics raised by computed
+// getters, effects and effect scopes instead of letting them unwind through
+// propagation.
+//
+// The tracking state that was live when the panicking node started running is
+// restored, so later reads and writes see a consistent graph. A panicking
+// effect or scope reports a *PanicError to the OnErrorFunc. A panicking
+// computed keeps its last value and caches the *PanicError like a ComputedErr
+// failure, surfacing it through ValueErr and Value.
+func WithPanicRecovery() Option {
+	return func(rs *ReactiveSystem) {
+		rs.recoverPanics = true
+	}
+}
+
+// PanicError is a recovered panic converted into an error.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func newPanicError(value any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered panic: %v\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the panic value if it was an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+type trackingState struct {
+	activeSub   *signal
+	activeScope *signal
+	batchDepth  int
+	pauseDepth  int
+	effectDepth int
+	cycle       *CycleError
+}
+
+func (rs *ReactiveSystem) saveTracking() trackingState {
+	return trackingState{
+		activeSub:   rs.activeSub,
+		activeScope: rs.activeScope,
+		batchDepth:  rs.batchDepth,
+		pauseDepth:  len(rs.pauseStack),
+		effectDepth: rs.effectDepth,
+		cycle:       rs.cycle,
+	}
+}
+
+// Restores the tracking state captured before a node started running.
+//
+// Batches opened with StartBatch but never ended because of the panic are
+// unwound, including the lock each of them holds in concurrent mode.
+//
+// @param state - The state captured by saveTracking.
+func (rs *ReactiveSystem) restoreTracking(state trackingState) {
+	rs.activeSub = state.activeSub
+	rs.activeScope = state.activeScope
+	unwound := rs.batchDepth > state.batchDepth
+	for rs.batchDepth > state.batchDepth {
+		rs.batchDepth--
+		if rs.mu != nil {
+			rs.mu.Unlock()
+		}
+	}
+	if unwound && rs.batchDepth == 0 && rs.recorders != nil {
+		rs.recordBatchEnd()
+	}
+	rs.pauseStack = rs.pauseStack[:state.pauseDepth]
+	rs.effectDepth = state.effectDepth
+	if rs.cycle != state.cycle {
+		// the panic unwound past the target of the pending cycle
+		rs.dropCycle()
+	}
+}
@@ -0,0 +1,95 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicRecoveryInEffect(t *testing.T) {
+	errs := []error{}
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs = append(errs, err)
+	}, alien.WithPanicRecovery())
+
+	a := alien.Signal(rs, 0)
+	b := alien.Signal(rs, 0)
+
+	alien.Effect(rs, func() error {
+		if a.Value() == 1 {
+			rs.StartBatch()
+			panic("boom")
+		}
+		return nil
+	})
+
+	bRuns := 0
+	alien.Effect(rs, func() error {
+		bRuns++
+		b.Value()
+		return nil
+	})
+
+	a.SetValue(1)
+	require.Len(t, errs, 1)
+	panicErr := &alien.PanicError{}
+	require.ErrorAs(t, errs[0], &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	// the batch opened before the panic was unwound, so writes flush again
+	b.SetValue(1)
+	assert.Equal(t, 2, bRuns)
+
+	// and the panicking effect is still subscribed
+	a.SetValue(2)
+	a.SetValue(1)
+	assert.Len(t, errs, 2)
+}
+
+func TestPanicRecoveryInComputed(t *testing.T) {
+	errs := []error{}
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		errs = append(errs, err)
+	}, alien.WithPanicRecovery())
+
+	a := alien.Signal(rs, 0)
+	b := alien.Computed(rs, func(oldValue int) int {
+		if a.Value() == 1 {
+			panic("fail")
+		}
+		return a.Value()
+	})
+	c := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() * 10
+	})
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, b.Value()+c.Value())
+		return nil
+	})
+
+	a.SetValue(1)
+	require.Len(t, errs, 1)
+	assert.ErrorAs(t, errs[0], new(*alien.PanicError))
+	assert.Equal(t, []int{0, 10}, seen)
+
+	_, err := b.ValueErr()
+	assert.ErrorAs(t, err, new(*alien.PanicError))
+
+	// tracking state survived, the graph still propagates
+	a.SetValue(2)
+	assert.Equal(t, []int{0, 10, 22}, seen)
+	_, err = b.ValueErr()
+	assert.NoError(t, err)
+
+	d := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + 1
+	})
+	assert.Equal(t, 3, d.Value())
+	a.SetValue(3)
+	assert.Equal(t, 4, d.Value())
+}
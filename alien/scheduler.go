@@ -0,0 +1,175 @@
+package alien
+
+import (
+	"sync"
+	"time"
+)
+
+// Scheduler decides when queued effects run.
+//
+// Without a scheduler the ReactiveSystem runs queued effects synchronously at
+// the end of SetValue or the outermost EndBatch.
+type Scheduler interface {
+	// Schedule receives the effects queued by a write or batch, in notification
+	// order. They stay notified, and won't be queued again, until they are
+	// passed to rs.RunEffects.
+	Schedule(rs *ReactiveSystem, effects []*EffectRunner)
+	// Flush runs everything scheduled so far, it is what rs.Flush calls.
+	Flush(rs *ReactiveSystem)
+}
+
+// WithScheduler hands queued effects to s instead of running them as soon as
+// a write or batch finishes.
+func WithScheduler(s Scheduler) Option {
+	return func(rs *ReactiveSystem) {
+		rs.scheduler = s
+	}
+}
+
+// Flush asks the scheduler to run every effect it is holding on to. Without a
+// scheduler effects never wait, so Flush does nothing.
+func (rs *ReactiveSystem) Flush() {
+	if rs.scheduler == nil {
+		return
+	}
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	rs.startFlush()
+	defer rs.endFlush()
+	rs.scheduler.Flush(rs)
+}
+
+// RunEffects runs effects previously handed to a Scheduler. Effects whose
+// dependencies turned out not to have changed, or that were stopped in the
+// meantime, are skipped.
+func (rs *ReactiveSystem) RunEffects(effects []*EffectRunner) {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	rs.startFlush()
+	defer rs.endFlush()
+	for _, e := range effects {
+		effect := &e.signal
+		if !rs.notifyEffect(effect) {
+			effect.flags = effect.flags & ^fNotified
+		}
+	}
+	if rs.batchDepth == 0 {
+		// post-flush effects of scopes are queued while their scope runs
+		rs.processEffectNotifications()
+	}
+}
+
+// Drains the queued effect notifications and hands them to the scheduler.
+func (rs *ReactiveSystem) scheduleEffectNotifications() {
+	if !rs.hasQueuedEffects() {
+		return
+	}
+	var effects []*EffectRunner
+	for rs.hasQueuedEffects() {
+		effect := rs.dequeueEffect()
+		effects = append(effects, effect.runner())
+	}
+	rs.scheduler.Schedule(rs, effects)
+}
+
+// SyncScheduler runs effects as soon as they are scheduled, the same as
+// having no scheduler at all.
+type SyncScheduler struct{}
+
+func (SyncScheduler) Schedule(rs *ReactiveSystem, effects []*EffectRunner) {
+	rs.RunEffects(effects)
+}
+
+func (SyncScheduler) Flush(rs *ReactiveSystem) {}
+
+// ManualScheduler holds effects until rs.Flush is called.
+type ManualScheduler struct {
+	mu      sync.Mutex
+	pending []*EffectRunner
+}
+
+func NewManualScheduler() *ManualScheduler {
+	return &ManualScheduler{}
+}
+
+func (s *ManualScheduler) Schedule(rs *ReactiveSystem, effects []*EffectRunner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, effects...)
+}
+
+// Flush runs the pending effects, including any scheduled while flushing.
+func (s *ManualScheduler) Flush(rs *ReactiveSystem) {
+	for {
+		s.mu.Lock()
+		pending := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+
+		if len(pending) == 0 {
+			return
+		}
+		rs.RunEffects(pending)
+	}
+}
+
+// Pending reports how many effects are waiting for the next flush.
+func (s *ManualScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// TickerScheduler coalesces effects and flushes them once per tick, like a
+// frame loop. It flushes from its own goroutine, so the ReactiveSystem must be
+// created WithConcurrency.
+type TickerScheduler struct {
+	ManualScheduler
+	rs     *ReactiveSystem
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+// NewTickerScheduler starts a scheduler that flushes every interval. Stop it
+// when the system is no longer used.
+func NewTickerScheduler(interval time.Duration) *TickerScheduler {
+	s := &TickerScheduler{
+		ticker: time.NewTicker(interval),
+		done:   make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+func (s *TickerScheduler) Schedule(rs *ReactiveSystem, effects []*EffectRunner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rs = rs
+	s.pending = append(s.pending, effects...)
+}
+
+func (s *TickerScheduler) loop() {
+	for {
+		select {
+		case <-s.ticker.C:
+			s.mu.Lock()
+			rs := s.rs
+			s.mu.Unlock()
+			if rs != nil {
+				// one tick is one flush for the convergence limit
+				rs.Flush()
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *TickerScheduler) Stop() {
+	s.ticker.Stop()
+	close(s.done)
+}
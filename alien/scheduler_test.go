@@ -0,0 +1,106 @@
+package alien_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSchedulerRunsImmediately(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithScheduler(alien.SyncScheduler{}))
+
+	count := alien.Signal(rs, 0)
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, count.Value())
+		return nil
+	})
+
+	count.SetValue(1)
+	count.SetValue(2)
+	assert.Equal(t, []int{0, 1, 2}, seen)
+}
+
+func TestManualSchedulerWaitsForFlush(t *testing.T) {
+	scheduler := alien.NewManualScheduler()
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithScheduler(scheduler))
+
+	a := alien.Signal(rs, 0)
+	b := alien.Signal(rs, 0)
+	sum := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + b.Value()
+	})
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, sum.Value())
+		return nil
+	})
+
+	a.SetValue(1)
+	b.SetValue(2)
+	a.SetValue(3)
+	assert.Equal(t, []int{0}, seen)
+	assert.Equal(t, 1, scheduler.Pending())
+
+	rs.Flush()
+	assert.Equal(t, []int{0, 5}, seen)
+	assert.Equal(t, 0, scheduler.Pending())
+
+	rs.Flush()
+	assert.Equal(t, []int{0, 5}, seen)
+}
+
+func TestManualSchedulerSkipsStoppedEffects(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithScheduler(alien.NewManualScheduler()))
+
+	count := alien.Signal(rs, 0)
+	runs := 0
+	stop := alien.Effect(rs, func() error {
+		runs++
+		count.Value()
+		return nil
+	})
+
+	count.SetValue(1)
+	stop()
+	rs.Flush()
+	assert.Equal(t, 1, runs)
+}
+
+func TestTickerSchedulerCoalescesWrites(t *testing.T) {
+	scheduler := alien.NewTickerScheduler(5 * time.Millisecond)
+	defer scheduler.Stop()
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency(), alien.WithScheduler(scheduler))
+
+	count := alien.Signal(rs, 0)
+	runs := atomic.Int32{}
+	last := atomic.Int32{}
+	alien.Effect(rs, func() error {
+		runs.Add(1)
+		last.Store(int32(count.Value()))
+		return nil
+	})
+
+	rs.Batch(func() {
+		for i := 1; i <= 100; i++ {
+			count.SetValue(i)
+		}
+	})
+
+	require.Eventually(t, func() bool {
+		return last.Load() == 100
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), runs.Load())
+}
@@ -0,0 +1,245 @@
+package alien
+
+import "errors"
+
+// ErrScopeStopped is returned by Scope.Run once the scope has been stopped.
+var ErrScopeStopped = errors.New("alien: scope is stopped")
+
+// Scope owns the effects, computeds and child scopes created inside its Run
+// calls and disposes them together.
+type Scope struct {
+	EffectRunner
+	rs       *ReactiveSystem
+	parent   *Scope
+	children []*Scope
+	stopped  bool
+}
+
+// NewScope creates an empty scope. When called while another scope is
+// running it becomes a child of that scope: it is paused with it and
+// disposed with it.
+func NewScope(rs *ReactiveSystem) *Scope {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	s := rs.newScope()
+	if rs.activeScope != nil {
+		parent := rs.activeScope.ref.(*Scope)
+		s.parent = parent
+		parent.children = append(parent.children, s)
+		rs.link(&s.signal, &parent.signal)
+	}
+	return s
+}
+
+// NewDetachedScope creates a scope that is never a child of the running
+// scope and only goes away when stopped itself.
+func NewDetachedScope(rs *ReactiveSystem) *Scope {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	return rs.newScope()
+}
+
+func (rs *ReactiveSystem) newScope() *Scope {
+	s := &Scope{
+		rs: rs,
+		EffectRunner: EffectRunner{
+			signal: signal{
+				flags: fEffect | fEffectScope,
+			},
+		},
+	}
+	s.signal.ref = s
+	rs.registerNode(&s.signal)
+	return s
+}
+
+// Run runs fn inside the scope and returns its error.
+//
+// Effects, child scopes and OnCleanup callbacks created by fn belong to the
+// scope, even when Run is called from within another effect. Run can be
+// called any number of times.
+func (s *Scope) Run(fn ErrFn) (err error) {
+	rs := s.rs
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	if s.stopped {
+		return ErrScopeStopped
+	}
+
+	prevSub, prevScope := rs.activeSub, rs.activeScope
+	if rs.recoverPanics {
+		state := rs.saveTracking()
+		defer func() {
+			if r := recover(); r != nil {
+				rs.restoreTracking(state)
+				err = newPanicError(r)
+			}
+		}()
+	}
+	rs.activeSub, rs.activeScope = nil, &s.signal
+	err = fn()
+	rs.activeSub, rs.activeScope = prevSub, prevScope
+	return err
+}
+
+// Pause holds back every effect in the scope and its child scopes. Effects
+// notified while paused run once on Resume.
+func (s *Scope) Pause() {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	if !s.stopped {
+		s.flags |= fPaused
+	}
+}
+
+// Resume runs the effects notified while the scope was paused.
+func (s *Scope) Resume() {
+	rs := s.rs
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	flags := s.flags
+	if flags&fPaused == 0 {
+		return
+	}
+	flags &^= fPaused
+	s.flags = flags
+	if flags&(fPendingEffect|fNotified) == fPendingEffect {
+		s.flags = flags | fNotified
+		rs.queueEffect(&s.signal)
+		if rs.batchDepth == 0 {
+			rs.processEffectNotifications()
+		}
+	}
+}
+
+// Paused reports whether the scope is paused.
+func (s *Scope) Paused() bool {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	return s.flags&fPaused != 0
+}
+
+// OnScopeDispose registers fn to run when the scope is stopped. Callbacks
+// run in LIFO order after the scope's effects and child scopes are gone.
+func (s *Scope) OnScopeDispose(fn func()) {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	if s.stopped {
+		return
+	}
+	s.cleanups = append(s.cleanups, fn)
+	s.flags |= fHasCleanups
+}
+
+// OnScopeDispose registers fn on the scope that is currently running, see
+// Scope.OnScopeDispose.
+func OnScopeDispose(rs *ReactiveSystem, fn func()) {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	if rs.activeScope == nil {
+		panic("OnScopeDispose must be called from within a scope")
+	}
+	rs.activeScope.ref.(*Scope).OnScopeDispose(fn)
+}
+
+// Stop disposes the child scopes in creation order, then the scope's own
+// effects and finally runs its OnScopeDispose callbacks.
+func (s *Scope) Stop() error {
+	rs := s.rs
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+
+	children := s.children
+	s.children = nil
+	for _, child := range children {
+		child.Stop()
+	}
+
+	signal := &s.signal
+	rs.startTracking(signal)
+	rs.endTracking(signal)
+	rs.runCleanups(signal)
+	signal.flags &^= fPaused
+
+	if parent := s.parent; parent != nil {
+		s.parent = nil
+		for i, child := range parent.children {
+			if child == s {
+				parent.children = append(parent.children[:i], parent.children[i+1:]...)
+				break
+			}
+		}
+		rs.unlinkDep(signal, &parent.signal)
+	}
+	return nil
+}
+
+// Removes the link between dep and sub, leaving the rest of both lists alone.
+//
+// @param dep - The dependency to remove.
+// @param sub - The subscriber that depends on dep.
+func (rs *ReactiveSystem) unlinkDep(dep, sub *signal) {
+	var prevDep *link
+	l := sub.deps
+	for l != nil && l.dep != dep {
+		prevDep = l
+		l = l.nextDep
+	}
+	if l == nil {
+		return
+	}
+
+	if prevDep != nil {
+		prevDep.nextDep = l.nextDep
+	} else {
+		sub.deps = l.nextDep
+	}
+	if sub.depsTail == l {
+		sub.depsTail = prevDep
+	}
+
+	if l.nextSub != nil {
+		l.nextSub.prevSub = l.prevSub
+	} else {
+		dep.subsTail = l.prevSub
+	}
+	if l.prevSub != nil {
+		l.prevSub.nextSub = l.nextSub
+	} else {
+		dep.subs = l.nextSub
+	}
+	if rs.tracer != nil {
+		rs.tracer.Unlink(rs.traceNode(dep), rs.traceNode(sub))
+	}
+	rs.releaseLink(l)
+}
+
+// Returns the EffectRunner of a queued effect or scope.
+func (s *signal) runner() *EffectRunner {
+	if scope, ok := s.ref.(*Scope); ok {
+		return &scope.EffectRunner
+	}
+	return s.ref.(*EffectRunner)
+}
@@ -0,0 +1,221 @@
+package alien_test
+
+import (
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeRunAddsEffectsLater(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 0)
+	scope := alien.NewScope(rs)
+
+	runs := []string{}
+	require.NoError(t, scope.Run(func() error {
+		alien.Effect(rs, func() error {
+			count.Value()
+			runs = append(runs, "first")
+			return nil
+		})
+		return nil
+	}))
+
+	// re-entering from inside another effect still adds to the scope
+	alien.Effect(rs, func() error {
+		return scope.Run(func() error {
+			alien.Effect(rs, func() error {
+				count.Value()
+				runs = append(runs, "second")
+				return nil
+			})
+			return nil
+		})
+	})
+	runs = runs[:0]
+
+	count.SetValue(1)
+	assert.Equal(t, []string{"first", "second"}, runs)
+
+	require.NoError(t, scope.Stop())
+	runs = runs[:0]
+	count.SetValue(2)
+	assert.Empty(t, runs)
+	assert.ErrorIs(t, scope.Run(func() error { return nil }), alien.ErrScopeStopped)
+}
+
+func TestScopePauseReplaysOnResume(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 0)
+	b := alien.Signal(rs, 0)
+
+	aRuns, bRuns, childRuns := 0, 0, 0
+	parent := alien.NewScope(rs)
+	parent.Run(func() error {
+		alien.Effect(rs, func() error {
+			aRuns++
+			a.Value()
+			return nil
+		})
+		alien.Effect(rs, func() error {
+			bRuns++
+			b.Value()
+			return nil
+		})
+		alien.NewScope(rs).Run(func() error {
+			alien.Effect(rs, func() error {
+				childRuns++
+				a.Value()
+				return nil
+			})
+			return nil
+		})
+		return nil
+	})
+
+	parent.Pause()
+	assert.True(t, parent.Paused())
+	a.SetValue(1)
+	a.SetValue(2)
+	assert.Equal(t, []int{1, 1, 1}, []int{aRuns, bRuns, childRuns})
+
+	parent.Resume()
+	assert.False(t, parent.Paused())
+	assert.Equal(t, []int{2, 1, 2}, []int{aRuns, bRuns, childRuns})
+
+	parent.Resume()
+	b.SetValue(1)
+	assert.Equal(t, []int{2, 2, 2}, []int{aRuns, bRuns, childRuns})
+}
+
+func TestChildScopePauseReplaysOnResume(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 0)
+
+	runs := 0
+	var child *alien.Scope
+	parent := alien.NewScope(rs)
+	parent.Run(func() error {
+		child = alien.NewScope(rs)
+		child.Run(func() error {
+			alien.Effect(rs, func() error {
+				runs++
+				a.Value()
+				return nil
+			})
+			return nil
+		})
+		return nil
+	})
+
+	child.Pause()
+	a.SetValue(1)
+	assert.Equal(t, 1, runs)
+
+	child.Resume()
+	assert.Equal(t, 2, runs)
+
+	a.SetValue(2)
+	assert.Equal(t, 3, runs)
+}
+
+func TestScopeDisposesChildrenInCreationOrder(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 0)
+	logs := []string{}
+
+	var detached *alien.Scope
+	parent := alien.NewScope(rs)
+	parent.OnScopeDispose(func() {
+		logs = append(logs, "parent")
+	})
+	parent.Run(func() error {
+		for _, name := range []string{"first", "second"} {
+			alien.NewScope(rs).Run(func() error {
+				alien.OnScopeDispose(rs, func() {
+					logs = append(logs, name)
+				})
+				alien.NewScope(rs).Run(func() error {
+					alien.OnScopeDispose(rs, func() {
+						logs = append(logs, name+" grandchild")
+					})
+					return nil
+				})
+				return nil
+			})
+		}
+
+		detached = alien.NewDetachedScope(rs)
+		detached.Run(func() error {
+			alien.Effect(rs, func() error {
+				count.Value()
+				logs = append(logs, "detached effect")
+				return nil
+			})
+			return nil
+		})
+		return nil
+	})
+	logs = logs[:0]
+
+	parent.Stop()
+	assert.Equal(t, []string{
+		"first grandchild",
+		"first",
+		"second grandchild",
+		"second",
+		"parent",
+	}, logs)
+
+	logs = logs[:0]
+	count.SetValue(1)
+	assert.Equal(t, []string{"detached effect"}, logs)
+	detached.Stop()
+}
+
+func TestStoppingChildLeavesParentRunning(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 0)
+
+	var child *alien.Scope
+	parentRuns, childRuns := 0, 0
+	parent := alien.NewScope(rs)
+	parent.Run(func() error {
+		child = alien.NewScope(rs)
+		child.Run(func() error {
+			alien.Effect(rs, func() error {
+				childRuns++
+				count.Value()
+				return nil
+			})
+			return nil
+		})
+		alien.Effect(rs, func() error {
+			parentRuns++
+			count.Value()
+			return nil
+		})
+		return nil
+	})
+
+	child.Stop()
+	count.SetValue(1)
+	assert.Equal(t, 2, parentRuns)
+	assert.Equal(t, 1, childRuns)
+
+	parent.Stop()
+	count.SetValue(2)
+	assert.Equal(t, 2, parentRuns)
+}
@@ -1,14 +1,30 @@
 package alien
 
-type WriteableSignal[T comparable] struct {
+type WriteableSignal[T any] struct {
 	signal
-	rs    *ReactiveSystem
-	value T
+	rs     *ReactiveSystem
+	value  T
+	equals func(a, b T) bool
+	key    string
+
+	writeFlush uint64
 }
 
 func (s *WriteableSignal[T]) isSignalAware() {}
 
 func (s *WriteableSignal[T]) Value() T {
+	if s.rs.mu != nil || s.rs.activeSub != nil {
+		return s.trackedValue()
+	}
+	return s.value
+}
+
+// Value when it has to lock or link, kept out of Value so that can be inlined.
+func (s *WriteableSignal[T]) trackedValue() T {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
 	if s.rs.activeSub != nil {
 		s.rs.link(&s.signal, s.rs.activeSub)
 	}
@@ -16,26 +32,107 @@ func (s *WriteableSignal[T]) Value() T {
 }
 
 func (s *WriteableSignal[T]) SetValue(v T) {
-	if s.value == v {
+	if s.rs.mu != nil {
+		s.lockedSetValue(v)
+		return
+	}
+	s.setValue(v)
+}
+
+// SetValue under the system lock, kept out of SetValue as a deferred unlock
+// slows down the unlocked case.
+func (s *WriteableSignal[T]) lockedSetValue(v T) {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	s.setValue(v)
+}
+
+func (s *WriteableSignal[T]) setValue(v T) {
+	if s.equals(s.value, v) {
+		return
+	}
+	rs := s.rs
+	if rs.hooked {
+		s.setValueHooked(v)
 		return
 	}
 	s.value = v
+	rs.writeEpoch++
+	s.changedAt = rs.writeEpoch
 	subs := s.signal.subs
 	if subs != nil {
-		s.rs.propagate(subs)
-		if s.rs.batchDepth == 0 {
-			s.rs.processEffectNotifications()
+		rs.propagate(subs)
+		if rs.batchDepth == 0 {
+			rs.processEffectNotifications()
+		}
+	}
+}
+
+// SetValue for a changed value while tracing, stats, recording or a flush is
+// going on.
+func (s *WriteableSignal[T]) setValueHooked(v T) {
+	rs := s.rs
+	if rs.tracer != nil {
+		rs.tracer.SignalWrite(rs.traceNode(&s.signal), s.value, v)
+	}
+	if rs.recorders != nil {
+		if rs.batchDepth == 0 {
+			rs.recordBatchStart()
+			defer rs.recordBatchEnd()
+		}
+		rs.recordWrite(s, s.value, v)
+	}
+	s.value = v
+	rs.writeEpoch++
+	if rs.stats != nil {
+		rs.stats.SignalWrites++
+	}
+	s.changedAt = rs.writeEpoch
+	if rs.flushDepth > 0 {
+		s.writeFlush = rs.flushID
+	}
+	subs := s.signal.subs
+	if subs != nil {
+		rs.propagate(subs)
+		if rs.batchDepth == 0 {
+			rs.processEffectNotifications()
 		}
 	}
 }
 
 func Signal[T comparable](rs *ReactiveSystem, initialValue T) *WriteableSignal[T] {
+	return SignalFunc(rs, initialValue, Equal[T])
+}
+
+// SignalFunc creates a signal for any type, using equals to decide whether a
+// write changed the value. A nil equals behaves like NeverEqual.
+func SignalFunc[T any](rs *ReactiveSystem, initialValue T, equals func(a, b T) bool) *WriteableSignal[T] {
+	if equals == nil {
+		equals = NeverEqual[T]
+	}
 	s := &WriteableSignal[T]{
 		rs:     rs,
 		value:  initialValue,
+		equals: equals,
 		signal: signal{},
 	}
 	signal := &s.signal
 	signal.ref = s
+	rs.registerNode(signal)
 	return s
 }
+
+func (s *WriteableSignal[T]) restore(v any) {
+	value, _ := v.(T)
+	s.SetValue(value)
+}
+
+func (s *WriteableSignal[T]) same(a, b any) bool {
+	x, _ := a.(T)
+	y, _ := b.(T)
+	return s.equals(x, y)
+}
+
+func (s *WriteableSignal[T]) lastWriteFlush() uint64 {
+	return s.writeFlush
+}
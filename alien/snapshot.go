@@ -0,0 +1,149 @@
+package alien
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrDuplicateKey is returned by SetKey when another signal already uses
+	// the key.
+	ErrDuplicateKey = errors.New("alien: signal key already in use")
+	// ErrUnknownKey is reported by Hydrate for keys no signal uses.
+	ErrUnknownKey = errors.New("alien: unknown signal key")
+	// ErrTypeMismatch is reported by Hydrate for values that can't be stored
+	// in the signal under their key.
+	ErrTypeMismatch = errors.New("alien: snapshot value has the wrong type")
+)
+
+type keyedSignal interface {
+	snapshotValue() any
+	decodeValue(v any) (func(), error)
+}
+
+// SetKey gives the signal a stable key under which it appears in
+// rs.Snapshot and is restored by rs.Hydrate. An empty key removes the
+// signal from snapshots.
+func (s *WriteableSignal[T]) SetKey(key string) error {
+	rs := s.rs
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	if key == s.key {
+		return nil
+	}
+	if _, ok := rs.keyed[key]; ok && key != "" {
+		return fmt.Errorf("%w: %q", ErrDuplicateKey, key)
+	}
+	if s.key != "" {
+		delete(rs.keyed, s.key)
+	}
+	s.key = key
+	if key == "" {
+		return nil
+	}
+	if rs.keyed == nil {
+		rs.keyed = map[string]keyedSignal{}
+	}
+	rs.keyed[key] = s
+	return nil
+}
+
+// Key returns the key set by SetKey.
+func (s *WriteableSignal[T]) Key() string {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	return s.key
+}
+
+func (s *WriteableSignal[T]) snapshotValue() any {
+	return s.value
+}
+
+// Converts a snapshot value to T and returns the write that applies it.
+//
+// Values are accepted as T, as json.RawMessage, or as anything
+// encoding/json turns into T, such as the float64s and maps produced by
+// decoding a snapshot into map[string]any.
+func (s *WriteableSignal[T]) decodeValue(v any) (func(), error) {
+	value, ok := v.(T)
+	if !ok {
+		raw, isRaw := v.(json.RawMessage)
+		if !isRaw {
+			var err error
+			if raw, err = json.Marshal(v); err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", ErrTypeMismatch, s.key, err)
+			}
+		}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrTypeMismatch, s.key, err)
+		}
+	}
+	return func() {
+		s.SetValue(value)
+	}, nil
+}
+
+// Snapshot returns the current value of every signal with a key. The result
+// can be encoded with encoding/json as long as the values can.
+func (rs *ReactiveSystem) Snapshot() map[string]any {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	snapshot := make(map[string]any, len(rs.keyed))
+	for key, s := range rs.keyed {
+		snapshot[key] = s.snapshotValue()
+	}
+	return snapshot
+}
+
+// Hydrate writes the values of a snapshot to the signals with matching keys
+// in a single batch, so computeds and effects settle once.
+//
+// Every key is checked before anything is written: if any key is unknown or
+// any value has the wrong type, nothing is written and the problems are
+// returned together, wrapping ErrUnknownKey and ErrTypeMismatch.
+func (rs *ReactiveSystem) Hydrate(snapshot map[string]any) error {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	keys := make([]string, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	writes := make([]func(), 0, len(snapshot))
+	var errs []error
+	for _, key := range keys {
+		v := snapshot[key]
+		s, ok := rs.keyed[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownKey, key))
+			continue
+		}
+		write, err := s.decodeValue(v)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		writes = append(writes, write)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	rs.Batch(func() {
+		for _, write := range writes {
+			write()
+		}
+	})
+	return nil
+}
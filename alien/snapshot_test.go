@@ -0,0 +1,107 @@
+package alien_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type prefs struct {
+	Theme string   `json:"theme"`
+	Tags  []string `json:"tags"`
+}
+
+func TestSnapshotRoundTripsThroughJSON(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	name := alien.Signal(rs, "ada")
+	age := alien.Signal(rs, 36)
+	settings := alien.SignalFunc(rs, prefs{Theme: "dark", Tags: []string{"math"}}, alien.DeepEqual[prefs])
+	alien.Signal(rs, "not persisted")
+	require.NoError(t, name.SetKey("user.name"))
+	require.NoError(t, age.SetKey("user.age"))
+	require.NoError(t, settings.SetKey("user.prefs"))
+
+	data, err := json.Marshal(rs.Snapshot())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"user.name": "ada",
+		"user.age": 36,
+		"user.prefs": {"theme": "dark", "tags": ["math"]}
+	}`, string(data))
+
+	other := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	name2 := alien.Signal(other, "")
+	age2 := alien.Signal(other, 0)
+	settings2 := alien.SignalFunc(other, prefs{}, alien.DeepEqual[prefs])
+	require.NoError(t, name2.SetKey("user.name"))
+	require.NoError(t, age2.SetKey("user.age"))
+	require.NoError(t, settings2.SetKey("user.prefs"))
+
+	summary := alien.Computed(other, func(oldValue string) string {
+		return name2.Value() + "/" + settings2.Value().Theme
+	})
+	runs := 0
+	alien.Effect(other, func() error {
+		runs++
+		summary.Value()
+		age2.Value()
+		return nil
+	})
+
+	snapshot := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	require.NoError(t, other.Hydrate(snapshot))
+	assert.Equal(t, 2, runs, "hydration settles once")
+	assert.Equal(t, "ada/dark", summary.Value())
+	assert.Equal(t, 36, age2.Value())
+	assert.Equal(t, prefs{Theme: "dark", Tags: []string{"math"}}, settings2.Value())
+
+	raw := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal([]byte(`{"user.age": 37}`), &raw))
+	require.NoError(t, other.Hydrate(map[string]any{"user.age": raw["user.age"]}))
+	assert.Equal(t, 37, age2.Value())
+}
+
+func TestHydrateReportsUnknownKeysAndMismatches(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+	label := alien.Signal(rs, "a")
+	require.NoError(t, count.SetKey("count"))
+	require.NoError(t, label.SetKey("label"))
+
+	err := rs.Hydrate(map[string]any{
+		"count":   "three",
+		"label":   "b",
+		"missing": 1,
+	})
+	assert.ErrorIs(t, err, alien.ErrTypeMismatch)
+	assert.ErrorIs(t, err, alien.ErrUnknownKey)
+	assert.Equal(t, 1, count.Value())
+	assert.Equal(t, "a", label.Value(), "nothing is written when any key fails")
+}
+
+func TestSetKey(t *testing.T) {
+	rs := alien.CreateReactiveSystem(nil)
+	a := alien.Signal(rs, 1)
+	b := alien.Signal(rs, 2)
+
+	require.NoError(t, a.SetKey("a"))
+	assert.ErrorIs(t, b.SetKey("a"), alien.ErrDuplicateKey)
+
+	require.NoError(t, a.SetKey("renamed"))
+	require.NoError(t, b.SetKey("a"))
+	assert.Equal(t, map[string]any{"renamed": 1, "a": 2}, rs.Snapshot())
+
+	require.NoError(t, a.SetKey(""))
+	assert.Equal(t, map[string]any{"a": 2}, rs.Snapshot())
+	assert.Equal(t, "a", b.Key())
+}
@@ -0,0 +1,50 @@
+package alien
+
+import (
+	"runtime"
+	"sync/atomic"
+
+	"github.com/delaneyj/signalparty/metrics"
+)
+
+type stats struct {
+	metrics.Stats
+	nodes  atomic.Int64
+	depths []uint64 // propagate's branch stack
+}
+
+// WithStats counts the work done by the ReactiveSystem, see Stats. Live
+// nodes are tracked with runtime cleanups, so a node only stops counting once
+// it has been garbage collected.
+func WithStats() Option {
+	return func(rs *ReactiveSystem) {
+		rs.stats = &stats{
+			Stats: metrics.Stats{
+				PropagationDepth: metrics.NewHistogram(metrics.DepthBuckets...),
+			},
+		}
+	}
+}
+
+// Stats returns a snapshot of the counters of a system created WithStats.
+// Without that option it returns zero Stats.
+func (rs *ReactiveSystem) Stats() metrics.Stats {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	if rs.stats == nil {
+		return metrics.Stats{}
+	}
+	s := rs.stats.Stats
+	s.PropagationDepth = s.PropagationDepth.Clone()
+	s.Nodes = rs.stats.nodes.Load()
+	return s
+}
+
+func (s *stats) addNode(n *signal) {
+	s.nodes.Add(1)
+	runtime.AddCleanup(n, func(nodes *atomic.Int64) {
+		nodes.Add(-1)
+	}, &s.nodes)
+}
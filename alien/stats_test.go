@@ -0,0 +1,82 @@
+package alien_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/delaneyj/signalparty/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsCountsWork(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithStats())
+
+	count := alien.Signal(rs, 1)
+	double := alien.Computed(rs, func(oldValue int) int {
+		return count.Value() * 2
+	})
+	quad := alien.Computed(rs, func(oldValue int) int {
+		return double.Value() * 2
+	})
+	stop := alien.Effect(rs, func() error {
+		quad.Value()
+		return nil
+	})
+
+	stats := rs.Stats()
+	assert.Equal(t, uint64(0), stats.SignalWrites)
+	assert.Equal(t, uint64(2), stats.ComputedRuns)
+	assert.Equal(t, uint64(1), stats.EffectRuns)
+	assert.Equal(t, int64(4), stats.Nodes)
+	assert.Equal(t, int64(3), stats.Links)
+
+	quad.Value()
+	count.SetValue(1)
+	count.SetValue(2)
+
+	stats = rs.Stats()
+	assert.Equal(t, uint64(1), stats.SignalWrites)
+	assert.Equal(t, uint64(4), stats.ComputedRuns)
+	assert.Equal(t, uint64(3), stats.ComputedHits)
+	assert.Equal(t, uint64(2), stats.EffectRuns)
+	assert.Equal(t, uint64(1), stats.PropagationDepth.Count)
+	assert.Equal(t, uint64(3), stats.PropagationDepth.Sum)
+
+	assert.NoError(t, stop())
+	assert.Equal(t, int64(0), rs.Stats().Links)
+}
+
+func TestStatsDropsCollectedNodes(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithStats())
+	count := alien.Signal(rs, 1)
+
+	func() {
+		for range 10 {
+			alien.Computed(rs, func(oldValue int) int {
+				return count.Value()
+			}).Value()
+		}
+	}()
+	assert.Equal(t, int64(0), rs.Stats().Links)
+
+	assert.Eventually(t, func() bool {
+		runtime.GC()
+		return rs.Stats().Nodes == 1
+	}, time.Second, time.Millisecond)
+	runtime.KeepAlive(count)
+}
+
+func TestStatsWithoutOption(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+	count.SetValue(2)
+	assert.Equal(t, metrics.Stats{}, rs.Stats())
+}
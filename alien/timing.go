@@ -0,0 +1,227 @@
+package alien
+
+import "time"
+
+// TimedSignal holds the output of a time operator such as Debounced. Every
+// timer firing writes it in its own batch, so it causes a single propagation.
+type TimedSignal[T any] struct {
+	rs      *ReactiveSystem
+	value   *WriteableSignal[T]
+	timers  map[Timer]struct{}
+	stop    ErrFn
+	stopped bool
+}
+
+func newTimedSignal[T any](rs *ReactiveSystem, initialValue T, equals func(a, b T) bool) *TimedSignal[T] {
+	if _, fake := rs.clock.(*FakeClock); !fake && rs.mu == nil {
+		// any other clock may fire timers on their own goroutines
+		panic("time operators require a ReactiveSystem created WithConcurrency unless its clock is a FakeClock")
+	}
+	return &TimedSignal[T]{
+		rs:     rs,
+		value:  SignalFunc(rs, initialValue, equals),
+		timers: map[Timer]struct{}{},
+	}
+}
+
+// Value returns the current output, tracked like any other signal read.
+func (s *TimedSignal[T]) Value() T {
+	return s.value.Value()
+}
+
+// Stop cancels pending timers and stops following the source.
+func (s *TimedSignal[T]) Stop() error {
+	if s.rs.mu != nil {
+		s.rs.mu.Lock()
+		defer s.rs.mu.Unlock()
+	}
+	if s.stopped {
+		return nil
+	}
+	s.stopped = true
+	for t := range s.timers {
+		t.Stop()
+	}
+	clear(s.timers)
+	if s.stop != nil {
+		return s.stop()
+	}
+	return nil
+}
+
+// Schedules fn on the system clock. It runs inside a batch unless the signal
+// has been stopped or the timer cancelled in the meantime.
+//
+// @param d - How long to wait.
+// @param fn - The write to make when the timer fires.
+// @returns The pending timer, for cancel.
+func (s *TimedSignal[T]) after(d time.Duration, fn func()) Timer {
+	var t Timer
+	t = s.rs.clock.AfterFunc(d, func() {
+		s.rs.Batch(func() {
+			if _, ok := s.timers[t]; !ok || s.stopped {
+				return
+			}
+			delete(s.timers, t)
+			fn()
+		})
+	})
+	s.timers[t] = struct{}{}
+	return t
+}
+
+func (s *TimedSignal[T]) cancel(t Timer) {
+	if _, ok := s.timers[t]; ok {
+		delete(s.timers, t)
+		t.Stop()
+	}
+}
+
+// Debounced follows src, but only takes a new value once src has stopped
+// changing for d.
+func Debounced[T comparable](rs *ReactiveSystem, src Source[T], d time.Duration) *TimedSignal[T] {
+	return DebouncedFunc(rs, src, d, Equal[T])
+}
+
+// DebouncedFunc is Debounced for any type, using equals to decide whether a
+// timer firing changed the output. A nil equals behaves like NeverEqual.
+func DebouncedFunc[T any](rs *ReactiveSystem, src Source[T], d time.Duration, equals func(a, b T) bool) *TimedSignal[T] {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	var zero T
+	s := newTimedSignal(rs, zero, equals)
+	started := false
+	s.stop = Effect(rs, func() error {
+		v := src.Value()
+		if !started {
+			started = true
+			s.value.value = v
+			return nil
+		}
+		t := s.after(d, func() {
+			s.value.SetValue(v)
+		})
+		OnCleanup(rs, func() {
+			s.cancel(t)
+		})
+		return nil
+	})
+	return s
+}
+
+// Throttled follows src at most once per d. The first change after a quiet
+// period goes through right away, later changes within d are folded into a
+// single trailing write of the latest value.
+func Throttled[T comparable](rs *ReactiveSystem, src Source[T], d time.Duration) *TimedSignal[T] {
+	return ThrottledFunc(rs, src, d, Equal[T])
+}
+
+// ThrottledFunc is Throttled for any type, using equals to decide whether a
+// write changed the output. A nil equals behaves like NeverEqual.
+func ThrottledFunc[T any](rs *ReactiveSystem, src Source[T], d time.Duration, equals func(a, b T) bool) *TimedSignal[T] {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	var zero T
+	s := newTimedSignal(rs, zero, equals)
+	var (
+		started, throttling, pending bool
+		latest                       T
+		openWindow                   func()
+	)
+	openWindow = func() {
+		throttling = true
+		s.after(d, func() {
+			if !pending {
+				throttling = false
+				return
+			}
+			pending = false
+			s.value.SetValue(latest)
+			latest = zero
+			openWindow()
+		})
+	}
+
+	s.stop = Effect(rs, func() error {
+		v := src.Value()
+		if !started {
+			started = true
+			s.value.value = v
+			return nil
+		}
+		if throttling {
+			latest, pending = v, true
+			return nil
+		}
+		s.value.SetValue(v)
+		openWindow()
+		return nil
+	})
+	return s
+}
+
+// Delayed follows src, taking every value d after src took it.
+func Delayed[T comparable](rs *ReactiveSystem, src Source[T], d time.Duration) *TimedSignal[T] {
+	return DelayedFunc(rs, src, d, Equal[T])
+}
+
+// DelayedFunc is Delayed for any type, using equals to decide whether a timer
+// firing changed the output. A nil equals behaves like NeverEqual.
+func DelayedFunc[T any](rs *ReactiveSystem, src Source[T], d time.Duration, equals func(a, b T) bool) *TimedSignal[T] {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	var zero T
+	s := newTimedSignal(rs, zero, equals)
+	started := false
+	s.stop = Effect(rs, func() error {
+		v := src.Value()
+		if !started {
+			started = true
+			s.value.value = v
+			return nil
+		}
+		s.after(d, func() {
+			s.value.SetValue(v)
+		})
+		return nil
+	})
+	return s
+}
+
+// Interval counts up by one every d, starting at 0.
+func Interval(rs *ReactiveSystem, d time.Duration) *TimedSignal[int] {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	s := newTimedSignal(rs, 0, Equal[int])
+	var tick func()
+	tick = func() {
+		s.value.SetValue(s.value.value + 1)
+		s.after(d, tick)
+	}
+	s.after(d, tick)
+	return s
+}
+
+// Now holds the current time of the system clock, refreshed every d.
+func Now(rs *ReactiveSystem, d time.Duration) *TimedSignal[time.Time] {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	s := newTimedSignal(rs, rs.clock.Now(), time.Time.Equal)
+	var tick func()
+	tick = func() {
+		s.value.SetValue(rs.clock.Now())
+		s.after(d, tick)
+	}
+	s.after(d, tick)
+	return s
+}
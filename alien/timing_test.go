@@ -0,0 +1,185 @@
+package alien_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClockedSystem(t *testing.T) (*alien.ReactiveSystem, *alien.FakeClock) {
+	clock := alien.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithClock(clock))
+	return rs, clock
+}
+
+func TestDebounced(t *testing.T) {
+	rs, clock := newClockedSystem(t)
+	query := alien.Signal(rs, "")
+	debounced := alien.Debounced(rs, query, 100*time.Millisecond)
+
+	seen := []string{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, debounced.Value())
+		return nil
+	})
+
+	query.SetValue("g")
+	clock.Advance(50 * time.Millisecond)
+	query.SetValue("go")
+	clock.Advance(99 * time.Millisecond)
+	assert.Equal(t, []string{""}, seen)
+
+	clock.Advance(time.Millisecond)
+	assert.Equal(t, []string{"", "go"}, seen)
+
+	query.SetValue("gop")
+	debounced.Stop()
+	clock.Advance(time.Second)
+	assert.Equal(t, []string{"", "go"}, seen)
+	assert.Zero(t, clock.Pending())
+}
+
+func TestDebouncedSkipsUnchangedValues(t *testing.T) {
+	rs, clock := newClockedSystem(t)
+	src := alien.Signal(rs, 3)
+	debounced := alien.Debounced(rs, src, 100*time.Millisecond)
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, debounced.Value())
+		return nil
+	})
+
+	src.SetValue(4)
+	src.SetValue(3)
+	clock.Advance(time.Second)
+	assert.Equal(t, []int{3}, seen)
+
+	tags := alien.SignalFunc(rs, []string{"a"}, nil)
+	debouncedTags := alien.DebouncedFunc(rs, tags, 100*time.Millisecond, alien.DeepEqual[[]string])
+	tagRuns := 0
+	alien.Effect(rs, func() error {
+		tagRuns++
+		debouncedTags.Value()
+		return nil
+	})
+	tags.SetValue([]string{"a"})
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, tagRuns)
+}
+
+func TestThrottled(t *testing.T) {
+	rs, clock := newClockedSystem(t)
+	pos := alien.Signal(rs, 0)
+	throttled := alien.Throttled(rs, pos, 100*time.Millisecond)
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, throttled.Value())
+		return nil
+	})
+
+	pos.SetValue(1)
+	pos.SetValue(2)
+	pos.SetValue(3)
+	assert.Equal(t, []int{0, 1}, seen, "leading change goes through")
+
+	clock.Advance(100 * time.Millisecond)
+	assert.Equal(t, []int{0, 1, 3}, seen, "trailing change carries the latest value")
+
+	clock.Advance(100 * time.Millisecond)
+	pos.SetValue(4)
+	assert.Equal(t, []int{0, 1, 3, 4}, seen)
+	clock.Advance(time.Second)
+	assert.Equal(t, []int{0, 1, 3, 4}, seen)
+}
+
+func TestDelayed(t *testing.T) {
+	rs, clock := newClockedSystem(t)
+	src := alien.Signal(rs, "a")
+	delayed := alien.Delayed(rs, src, time.Second)
+
+	src.SetValue("b")
+	clock.Advance(500 * time.Millisecond)
+	src.SetValue("c")
+	assert.Equal(t, "a", delayed.Value())
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, "b", delayed.Value())
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, "c", delayed.Value())
+}
+
+func TestIntervalAndNow(t *testing.T) {
+	rs, clock := newClockedSystem(t)
+	start := clock.Now()
+	ticks := alien.Interval(rs, time.Second)
+	now := alien.Now(rs, time.Minute)
+
+	clock.Advance(3500 * time.Millisecond)
+	assert.Equal(t, 3, ticks.Value())
+	assert.Equal(t, start, now.Value())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, 63, ticks.Value())
+	assert.Equal(t, start.Add(time.Minute), now.Value())
+
+	ticks.Stop()
+	now.Stop()
+	clock.Advance(time.Hour)
+	assert.Equal(t, 63, ticks.Value())
+	assert.Zero(t, clock.Pending())
+}
+
+func TestTimerFiringPropagatesOnce(t *testing.T) {
+	rs, clock := newClockedSystem(t)
+	ticks := alien.Interval(rs, time.Second)
+	doubled := alien.Computed(rs, func(oldValue int) int {
+		return ticks.Value() * 2
+	})
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		runs++
+		ticks.Value()
+		doubled.Value()
+		return nil
+	})
+
+	clock.Advance(time.Second)
+	assert.Equal(t, 2, runs)
+}
+
+func TestDebouncedWithRealClock(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithConcurrency())
+	src := alien.Signal(rs, 0)
+	debounced := alien.Debounced(rs, src, 10*time.Millisecond)
+	defer debounced.Stop()
+
+	for i := 1; i <= 5; i++ {
+		src.SetValue(i)
+	}
+	assert.Eventually(t, func() bool {
+		return debounced.Value() == 5
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestTimeOperatorsRequireConcurrencyWithRealClock(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	src := alien.Signal(rs, 0)
+
+	assert.Panics(t, func() { alien.Debounced(rs, src, time.Millisecond) })
+	assert.Panics(t, func() { alien.Throttled(rs, src, time.Millisecond) })
+	assert.Panics(t, func() { alien.Delayed(rs, src, time.Millisecond) })
+	assert.Panics(t, func() { alien.Interval(rs, time.Millisecond) })
+	assert.Panics(t, func() { alien.Now(rs, time.Millisecond) })
+	assert.Nil(t, rs.Inspect(src).Subs, "nothing was left following src")
+}
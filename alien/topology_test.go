@@ -407,6 +407,48 @@ func TestShouldEnsureSubsUpdateEvenIfAllDepsUnmarkIt(t *testing.T) {
 	assert.Equal(t, 0, dCallCount)
 }
 
+func TestShouldUpdatePendingComputedWithSeveralSubs(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+
+	//     A
+	//     |
+	//     B
+	//     |
+	//     C
+	//   /   \
+	//  D     E
+	a := alien.Signal(rs, 1)
+	b := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() * 2
+	})
+	c := alien.Computed(rs, func(oldValue int) int {
+		return b.Value() + 1
+	})
+	d := alien.Computed(rs, func(oldValue int) int {
+		return c.Value() * 10
+	})
+	e := alien.Computed(rs, func(oldValue int) int {
+		return c.Value() * 100
+	})
+
+	var dSeen, eSeen []int
+	alien.Effect(rs, func() error {
+		dSeen = append(dSeen, d.Value())
+		return nil
+	})
+	alien.Effect(rs, func() error {
+		eSeen = append(eSeen, e.Value())
+		return nil
+	})
+
+	a.SetValue(2)
+	a.SetValue(3)
+	assert.Equal(t, []int{30, 50, 70}, dSeen)
+	assert.Equal(t, []int{300, 500, 700}, eSeen)
+}
+
 func TestShouldKeepGraphConsistentOnActivationErrors(t *testing.T) {
 	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
 		t.Error(err)
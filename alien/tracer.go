@@ -0,0 +1,224 @@
+package alien
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+)
+
+// Tracer observes propagation inside a ReactiveSystem. Callbacks run
+// synchronously while the system is mid-update, so they must not read or
+// write signals.
+type Tracer interface {
+	// SignalWrite is called when a write changes a signal's value.
+	SignalWrite(node TraceNode, oldValue, newValue any)
+	ComputeStart(node TraceNode)
+	// ComputeEnd reports whether the recomputation changed the value.
+	ComputeEnd(node TraceNode, changed bool)
+	EffectRun(node TraceNode)
+	BatchStart(depth int)
+	BatchEnd(depth int)
+	Link(dep, sub TraceNode)
+	Unlink(dep, sub TraceNode)
+}
+
+// WithTracer reports propagation events to t. Without a tracer the hooks cost
+// a nil check.
+func WithTracer(t Tracer) Option {
+	return func(rs *ReactiveSystem) {
+		rs.tracer = t
+	}
+}
+
+// TraceNode identifies the node a trace event is about.
+type TraceNode struct {
+	ID    NodeID
+	Label string
+	Kind  NodeKind
+}
+
+// Name returns the label of the node, or its kind and id when unlabelled.
+func (n TraceNode) Name() string {
+	return nodeName(n.ID, n.Label, n.Kind)
+}
+
+func (rs *ReactiveSystem) traceNode(n *signal) TraceNode {
+	return TraceNode{
+		ID:    rs.nodeID(n),
+		Label: n.label,
+		Kind:  nodeKind(n.flags),
+	}
+}
+
+func nodeName(id NodeID, label string, kind NodeKind) string {
+	if label != "" {
+		return label
+	}
+	return kind.String() + "#" + strconv.FormatUint(uint64(id), 10)
+}
+
+// SlogTracer logs every trace event at a fixed level.
+type SlogTracer struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func NewSlogTracer(logger *slog.Logger, level slog.Level) *SlogTracer {
+	return &SlogTracer{logger: logger, level: level}
+}
+
+func (t *SlogTracer) log(msg string, attrs ...slog.Attr) {
+	t.logger.LogAttrs(context.Background(), t.level, msg, attrs...)
+}
+
+func (t *SlogTracer) SignalWrite(node TraceNode, oldValue, newValue any) {
+	t.log("signal write",
+		slog.String("node", node.Name()),
+		slog.Any("old", oldValue),
+		slog.Any("new", newValue),
+	)
+}
+
+func (t *SlogTracer) ComputeStart(node TraceNode) {
+	t.log("compute start", slog.String("node", node.Name()))
+}
+
+func (t *SlogTracer) ComputeEnd(node TraceNode, changed bool) {
+	t.log("compute end", slog.String("node", node.Name()), slog.Bool("changed", changed))
+}
+
+func (t *SlogTracer) EffectRun(node TraceNode) {
+	t.log("effect run", slog.String("node", node.Name()))
+}
+
+func (t *SlogTracer) BatchStart(depth int) {
+	t.log("batch start", slog.Int("depth", depth))
+}
+
+func (t *SlogTracer) BatchEnd(depth int) {
+	t.log("batch end", slog.Int("depth", depth))
+}
+
+func (t *SlogTracer) Link(dep, sub TraceNode) {
+	t.log("link", slog.String("dep", dep.Name()), slog.String("sub", sub.Name()))
+}
+
+func (t *SlogTracer) Unlink(dep, sub TraceNode) {
+	t.log("unlink", slog.String("dep", dep.Name()), slog.String("sub", sub.Name()))
+}
+
+type TraceEventKind uint8
+
+const (
+	TraceSignalWrite TraceEventKind = iota
+	TraceComputeStart
+	TraceComputeEnd
+	TraceEffectRun
+	TraceBatchStart
+	TraceBatchEnd
+	TraceLink
+	TraceUnlink
+)
+
+func (k TraceEventKind) String() string {
+	switch k {
+	case TraceSignalWrite:
+		return "signalWrite"
+	case TraceComputeStart:
+		return "computeStart"
+	case TraceComputeEnd:
+		return "computeEnd"
+	case TraceEffectRun:
+		return "effectRun"
+	case TraceBatchStart:
+		return "batchStart"
+	case TraceBatchEnd:
+		return "batchEnd"
+	case TraceLink:
+		return "link"
+	case TraceUnlink:
+		return "unlink"
+	default:
+		return "unknown"
+	}
+}
+
+// TraceEvent is one event captured by a TraceRecorder. Only the fields that
+// apply to Kind are set: Node for node events, Dep and Sub for (un)links,
+// OldValue and NewValue for writes, Changed for ComputeEnd and Depth for
+// batches.
+type TraceEvent struct {
+	Kind     TraceEventKind
+	Node     TraceNode
+	Dep      TraceNode
+	Sub      TraceNode
+	OldValue any
+	NewValue any
+	Changed  bool
+	Depth    int
+}
+
+// TraceRecorder keeps every trace event in memory, handy for tests and
+// debugging sessions.
+type TraceRecorder struct {
+	mu     sync.Mutex
+	events []TraceEvent
+}
+
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{}
+}
+
+// Events returns a copy of the recorded events in the order they happened.
+func (r *TraceRecorder) Events() []TraceEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := make([]TraceEvent, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+func (r *TraceRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+func (r *TraceRecorder) record(e TraceEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *TraceRecorder) SignalWrite(node TraceNode, oldValue, newValue any) {
+	r.record(TraceEvent{Kind: TraceSignalWrite, Node: node, OldValue: oldValue, NewValue: newValue})
+}
+
+func (r *TraceRecorder) ComputeStart(node TraceNode) {
+	r.record(TraceEvent{Kind: TraceComputeStart, Node: node})
+}
+
+func (r *TraceRecorder) ComputeEnd(node TraceNode, changed bool) {
+	r.record(TraceEvent{Kind: TraceComputeEnd, Node: node, Changed: changed})
+}
+
+func (r *TraceRecorder) EffectRun(node TraceNode) {
+	r.record(TraceEvent{Kind: TraceEffectRun, Node: node})
+}
+
+func (r *TraceRecorder) BatchStart(depth int) {
+	r.record(TraceEvent{Kind: TraceBatchStart, Depth: depth})
+}
+
+func (r *TraceRecorder) BatchEnd(depth int) {
+	r.record(TraceEvent{Kind: TraceBatchEnd, Depth: depth})
+}
+
+func (r *TraceRecorder) Link(dep, sub TraceNode) {
+	r.record(TraceEvent{Kind: TraceLink, Dep: dep, Sub: sub})
+}
+
+func (r *TraceRecorder) Unlink(dep, sub TraceNode) {
+	r.record(TraceEvent{Kind: TraceUnlink, Dep: dep, Sub: sub})
+}
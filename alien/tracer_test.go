@@ -0,0 +1,152 @@
+package alien_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceRecorderCapturesPropagation(t *testing.T) {
+	recorder := alien.NewTraceRecorder()
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithTracer(recorder))
+
+	count := alien.Signal(rs, 1)
+	count.SetLabel("count")
+	parity := alien.Computed(rs, func(oldValue bool) bool {
+		return count.Value()%2 == 0
+	})
+	parity.SetLabel("parity")
+	alien.Effect(rs, func() error {
+		alien.Label(rs, "log")
+		parity.Value()
+		return nil
+	})
+
+	recorder.Reset()
+	rs.Batch(func() {
+		count.SetValue(3)
+	})
+
+	kinds := []alien.TraceEventKind{}
+	for _, e := range recorder.Events() {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []alien.TraceEventKind{
+		alien.TraceBatchStart,
+		alien.TraceSignalWrite,
+		alien.TraceBatchEnd,
+		alien.TraceComputeStart,
+		alien.TraceComputeEnd,
+	}, kinds)
+
+	events := recorder.Events()
+	assert.Equal(t, "count", events[1].Node.Name())
+	assert.Equal(t, 1, events[1].OldValue)
+	assert.Equal(t, 3, events[1].NewValue)
+	assert.Equal(t, "parity", events[4].Node.Name())
+	assert.False(t, events[4].Changed, "odd to odd keeps parity, the effect is skipped")
+
+	recorder.Reset()
+	count.SetValue(4)
+	events = recorder.Events()
+	require.Len(t, events, 4)
+	assert.True(t, events[2].Changed)
+	assert.Equal(t, alien.TraceEffectRun, events[3].Kind)
+	assert.Equal(t, "log", events[3].Node.Name())
+}
+
+func TestTraceRecorderCapturesLinks(t *testing.T) {
+	recorder := alien.NewTraceRecorder()
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithTracer(recorder))
+
+	toggle := alien.Signal(rs, true)
+	toggle.SetLabel("toggle")
+	a := alien.Signal(rs, 0)
+	a.SetLabel("a")
+	stop := alien.Effect(rs, func() error {
+		alien.Label(rs, "effect")
+		if toggle.Value() {
+			a.Value()
+		}
+		return nil
+	})
+
+	links := func(kind alien.TraceEventKind) []string {
+		out := []string{}
+		for _, e := range recorder.Events() {
+			if e.Kind == kind {
+				out = append(out, e.Dep.Name()+"->"+e.Sub.Name())
+			}
+		}
+		return out
+	}
+	assert.Equal(t, []string{"toggle->effect", "a->effect"}, links(alien.TraceLink))
+
+	recorder.Reset()
+	toggle.SetValue(false)
+	assert.Equal(t, []string{"a->effect"}, links(alien.TraceUnlink))
+
+	recorder.Reset()
+	stop()
+	assert.Equal(t, []string{"toggle->effect"}, links(alien.TraceUnlink))
+}
+
+func TestTraceRecorderCapturesDetachAndAttach(t *testing.T) {
+	recorder := alien.NewTraceRecorder()
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithTracer(recorder))
+
+	count := alien.Signal(rs, 1)
+	count.SetLabel("count")
+	double := alien.Computed(rs, func(oldValue int) int {
+		return count.Value() * 2
+	})
+	double.SetLabel("double")
+
+	links := func() []string {
+		out := []string{}
+		for _, e := range recorder.Events() {
+			switch e.Kind {
+			case alien.TraceLink:
+				out = append(out, "+"+e.Dep.Name()+"->"+e.Sub.Name())
+			case alien.TraceUnlink:
+				out = append(out, "-"+e.Dep.Name()+"->"+e.Sub.Name())
+			}
+		}
+		return out
+	}
+
+	assert.Equal(t, 2, double.Value())
+	assert.Equal(t, []string{"+count->double", "-count->double"}, links(), "unobserved reads detach")
+
+	recorder.Reset()
+	alien.Effect(rs, func() error {
+		alien.Label(rs, "effect")
+		double.Value()
+		return nil
+	})
+	assert.Equal(t, []string{"+count->double", "+double->effect"}, links())
+}
+
+func TestSlogTracer(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithTracer(alien.NewSlogTracer(logger, slog.LevelDebug)))
+
+	count := alien.Signal(rs, 1)
+	count.SetLabel("count")
+	count.SetValue(2)
+
+	assert.Contains(t, buf.String(), `msg="signal write" node=count old=1 new=2`)
+}
@@ -0,0 +1,123 @@
+package alien
+
+// transaction records what a Transaction needs to undo its writes.
+type transaction struct {
+	targets   []writeTarget
+	oldValues map[writeTarget]any
+	computeds map[*signal]func()
+	// effects that only became dirty through the transaction
+	effects map[*signal]bool
+}
+
+// Transaction runs fn in a batch and keeps its writes only if it succeeds.
+//
+// If fn returns an error or panics, every WriteableSignal it wrote gets its
+// value from before the transaction back and the effects notified by those
+// writes don't run. Writes to ReactiveMaps and ReactiveSlices are not rolled
+// back. A panic is returned as a *PanicError when the system was created
+// WithPanicRecovery and re-panicked otherwise. Transactions can be nested, an
+// inner one only rolls back its own writes.
+func (rs *ReactiveSystem) Transaction(fn func() error) (err error) {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+
+	t := &transaction{
+		oldValues: map[writeTarget]any{},
+		computeds: map[*signal]func(){},
+		effects:   map[*signal]bool{},
+	}
+	rs.transactions = append(rs.transactions, t)
+	rs.addRecorder(t)
+	rs.StartBatch()
+	state := rs.saveTracking()
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			rs.restoreTracking(state)
+			if rs.recoverPanics {
+				err = newPanicError(r)
+			}
+		}
+		if err != nil || r != nil {
+			t.rollback(rs)
+		}
+		rs.removeRecorder(t)
+		if rs.transactions = rs.transactions[:len(rs.transactions)-1]; len(rs.transactions) == 0 {
+			rs.transactions = nil
+		}
+		rs.updateHooked()
+		rs.EndBatch()
+		if r != nil && !rs.recoverPanics {
+			panic(r)
+		}
+	}()
+	return fn()
+}
+
+// Writes back the old values and makes sure nothing the transaction touched
+// looks changed once the batch ends.
+//
+// Restoring a signal marks its subscribers again. Computeds that recomputed
+// during the transaction get their old cached value back, so recomputing them
+// finds nothing changed, and effects that were only dirty because of the
+// rolled back writes are downgraded to checking their computeds.
+//
+// @param rs - The system the transaction ran in.
+func (t *transaction) rollback(rs *ReactiveSystem) {
+	for i := len(t.targets) - 1; i >= 0; i-- {
+		target := t.targets[i]
+		target.restore(t.oldValues[target])
+	}
+	for c, restore := range t.computeds {
+		if c.flags&(fDirty|fPendingComputed) != 0 {
+			restore()
+		}
+	}
+	for e := range t.effects {
+		if flags := e.flags; flags&fDirty != 0 {
+			e.flags = flags&^fDirty | fPendingComputed
+		}
+	}
+}
+
+// Remembers the cached value of a computed before it recomputes inside the
+// running transactions.
+//
+// @param c - The computed about to recompute.
+func (rs *ReactiveSystem) saveComputed(c *signal) {
+	for _, t := range rs.transactions {
+		if _, ok := t.computeds[c]; !ok {
+			t.computeds[c] = c.ref.(computedAny).save()
+			t.saveEffects(c)
+		}
+	}
+}
+
+// Remembers the effects subscribed to a node that aren't dirty yet, before
+// the transaction changes it.
+//
+// @param n - The signal about to be written or the computed about to
+// recompute.
+func (t *transaction) saveEffects(n *signal) {
+	for l := n.subs; l != nil; l = l.nextSub {
+		if sub := l.sub; sub.flags&(fEffect|fDirty) == fEffect {
+			t.effects[sub] = true
+		}
+	}
+}
+
+func (t *transaction) recordWrite(target writeTarget, oldValue, newValue any) {
+	if _, ok := t.oldValues[target]; ok {
+		return
+	}
+	t.targets = append(t.targets, target)
+	t.oldValues[target] = oldValue
+	t.saveEffects(target.node())
+}
+
+func (t *transaction) batchStarted() {}
+
+func (t *transaction) batchEnded() {}
@@ -0,0 +1,158 @@
+package alien_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionCommits(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 1)
+	b := alien.Signal(rs, 1)
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		runs++
+		a.Value()
+		b.Value()
+		return nil
+	})
+
+	err := rs.Transaction(func() error {
+		a.SetValue(2)
+		b.SetValue(3)
+		assert.Equal(t, 1, runs)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, a.Value())
+	assert.Equal(t, 3, b.Value())
+	assert.Equal(t, 2, runs)
+}
+
+func TestTransactionRollsBackOnError(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 1)
+	b := alien.Signal(rs, 1)
+	sum := alien.Computed(rs, func(oldValue int) int {
+		return a.Value() + b.Value()
+	})
+
+	seen := []int{}
+	alien.Effect(rs, func() error {
+		seen = append(seen, a.Value())
+		return nil
+	})
+	sums := []int{}
+	alien.Effect(rs, func() error {
+		sums = append(sums, sum.Value())
+		return nil
+	})
+
+	boom := errors.New("boom")
+	err := rs.Transaction(func() error {
+		a.SetValue(2)
+		assert.Equal(t, 3, sum.Value())
+		b.SetValue(5)
+		a.SetValue(3)
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, a.Value())
+	assert.Equal(t, 1, b.Value())
+	assert.Equal(t, 2, sum.Value())
+	assert.Equal(t, []int{1}, seen)
+	assert.Equal(t, []int{2}, sums)
+
+	a.SetValue(4)
+	assert.Equal(t, []int{1, 4}, seen)
+	assert.Equal(t, []int{2, 5}, sums)
+}
+
+func TestTransactionRollsBackOnPanic(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 1)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		rs.Transaction(func() error {
+			a.SetValue(2)
+			rs.StartBatch()
+			panic("boom")
+		})
+	})
+	assert.Equal(t, 1, a.Value())
+
+	runs := 0
+	alien.Effect(rs, func() error {
+		runs++
+		a.Value()
+		return nil
+	})
+	a.SetValue(2)
+	assert.Equal(t, 2, runs)
+}
+
+func TestTransactionReturnsPanicError(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	}, alien.WithPanicRecovery())
+	a := alien.Signal(rs, 1)
+
+	err := rs.Transaction(func() error {
+		a.SetValue(2)
+		panic("boom")
+	})
+	panicErr := &alien.PanicError{}
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Equal(t, 1, a.Value())
+}
+
+func TestNestedTransactionRollsBackOwnWrites(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 1)
+	b := alien.Signal(rs, 1)
+
+	err := rs.Transaction(func() error {
+		a.SetValue(2)
+		innerErr := rs.Transaction(func() error {
+			a.SetValue(3)
+			b.SetValue(3)
+			return errors.New("inner")
+		})
+		assert.Error(t, innerErr)
+		assert.Equal(t, 2, a.Value())
+		assert.Equal(t, 1, b.Value())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, a.Value())
+	assert.Equal(t, 1, b.Value())
+}
+
+func TestRolledBackTransactionLeavesNoHistory(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	a := alien.Signal(rs, 1)
+	h := alien.NewHistory(rs)
+	h.Track(a)
+
+	rs.Transaction(func() error {
+		a.SetValue(2)
+		return errors.New("boom")
+	})
+	assert.False(t, h.CanUndo())
+}
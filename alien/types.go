@@ -12,6 +12,12 @@ const (
 	fPendingComputed
 	fPendingEffect
 	fEffectScope
+	fHasCleanups
+	fFlushPre
+	fFlushPost
+	fPaused
+	fChecking
+	fDetached
 	fPropagated subscriberFlags = fDirty | fPendingComputed | fPendingEffect
 )
 
@@ -27,4 +33,14 @@ type signal struct {
 	ref                            interface{}
 	flags                          subscriberFlags
 	deps, depsTail, subs, subsTail *link
+
+	id    NodeID
+	label string
+
+	// write epoch of the last change, see detach
+	changedAt uint64
+}
+
+func (s *signal) node() *signal {
+	return s
 }
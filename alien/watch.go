@@ -0,0 +1,208 @@
+package alien
+
+// Source is anything Watch can observe: a WriteableSignal, a ReadonlySignal,
+// an AsyncSignal or a Getter.
+type Source[T any] interface {
+	Value() T
+}
+
+// Getter adapts a plain function to a Source. Every signal it reads is
+// tracked, so the watcher re-runs it whenever one of them changes.
+type Getter[T any] func() T
+
+func (g Getter[T]) Value() T {
+	return g()
+}
+
+// FlushTiming decides when a watcher's callback runs relative to the other
+// effects notified by the same write.
+type FlushTiming uint8
+
+const (
+	// FlushPre runs the callback before other effects. This is the default.
+	FlushPre FlushTiming = iota
+	// FlushPost runs the callback after every other effect has run.
+	FlushPost
+)
+
+type watchConfig struct {
+	immediate bool
+	once      bool
+	flush     FlushTiming
+}
+
+type WatchOption func(c *watchConfig)
+
+// WatchImmediate calls the callback right away with the current value and
+// the zero value as the old value.
+func WatchImmediate() WatchOption {
+	return func(c *watchConfig) {
+		c.immediate = true
+	}
+}
+
+// WatchOnce stops the watcher after the callback has been called once.
+func WatchOnce() WatchOption {
+	return func(c *watchConfig) {
+		c.once = true
+	}
+}
+
+// WatchFlush sets when the callback runs relative to other effects.
+func WatchFlush(timing FlushTiming) WatchOption {
+	return func(c *watchConfig) {
+		c.flush = timing
+	}
+}
+
+// WatchHandle controls a watcher created by Watch.
+type WatchHandle struct {
+	rs      *ReactiveSystem
+	stop    ErrFn
+	stopped bool
+	paused  bool
+	resume  func()
+}
+
+// Watch calls cb with the new and old value every time the value of source
+// changes.
+//
+// The callback itself is not tracked, it may read and write signals freely.
+func Watch[T comparable](
+	rs *ReactiveSystem,
+	source Source[T],
+	cb func(newValue, oldValue T),
+	opts ...WatchOption,
+) *WatchHandle {
+	return WatchFunc(rs, source, cb, Equal[T], opts...)
+}
+
+// WatchFunc is Watch for any type, using equals to decide whether the value
+// of source changed.
+func WatchFunc[T any](
+	rs *ReactiveSystem,
+	source Source[T],
+	cb func(newValue, oldValue T),
+	equals func(a, b T) bool,
+	opts ...WatchOption,
+) *WatchHandle {
+	if rs.mu != nil {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+	}
+	cfg := watchConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if equals == nil {
+		equals = NeverEqual[T]
+	}
+	flags := fFlushPre
+	if cfg.flush == FlushPost {
+		flags = fFlushPost
+	}
+
+	w := &WatchHandle{rs: rs}
+	var (
+		oldValue, pendingValue T
+		started, pending       bool
+	)
+	fire := func(newValue, prevValue T) {
+		rs.PauseTracking()
+		cb(newValue, prevValue)
+		rs.ResumeTracking()
+		if cfg.once {
+			w.Stop()
+		}
+	}
+	w.resume = func() {
+		if !pending {
+			return
+		}
+		newValue := pendingValue
+		var zero T
+		pending, pendingValue = false, zero
+		if equals(oldValue, newValue) {
+			return
+		}
+		prevValue := oldValue
+		oldValue = newValue
+		fire(newValue, prevValue)
+	}
+
+	stop := rs.effect(func() error {
+		if w.stopped {
+			return nil
+		}
+		newValue := source.Value()
+		if !started {
+			started = true
+			oldValue = newValue
+			if cfg.immediate {
+				var zero T
+				fire(newValue, zero)
+			}
+			return nil
+		}
+		if w.paused {
+			pendingValue, pending = newValue, true
+			return nil
+		}
+		if equals(oldValue, newValue) {
+			return nil
+		}
+		prevValue := oldValue
+		oldValue = newValue
+		fire(newValue, prevValue)
+		return nil
+	}, flags)
+
+	w.stop = stop
+	if w.stopped {
+		// WatchOnce together with WatchImmediate stops during the first run
+		stop()
+	}
+	return w
+}
+
+// Stop stops the watcher, the callback is never called again.
+func (w *WatchHandle) Stop() error {
+	if w.rs.mu != nil {
+		w.rs.mu.Lock()
+		defer w.rs.mu.Unlock()
+	}
+	if w.stopped {
+		return nil
+	}
+	w.stopped = true
+	if w.stop == nil {
+		return nil
+	}
+	return w.stop()
+}
+
+// Pause holds back the callback. Changes keep being tracked while paused.
+func (w *WatchHandle) Pause() {
+	if w.rs.mu != nil {
+		w.rs.mu.Lock()
+		defer w.rs.mu.Unlock()
+	}
+	w.paused = true
+}
+
+// Resume re-enables the callback. If the value changed while paused the
+// callback is called once with the latest value and the value from before the
+// pause.
+func (w *WatchHandle) Resume() {
+	if w.rs.mu != nil {
+		w.rs.mu.Lock()
+		defer w.rs.mu.Unlock()
+	}
+	if !w.paused {
+		return
+	}
+	w.paused = false
+	if !w.stopped {
+		w.resume()
+	}
+}
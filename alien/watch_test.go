@@ -0,0 +1,189 @@
+package alien_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/delaneyj/signalparty/alien"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchSignalPassesOldAndNewValues(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	calls := [][2]int{}
+	w := alien.Watch(rs, count, func(newValue, oldValue int) {
+		calls = append(calls, [2]int{newValue, oldValue})
+	})
+	assert.Empty(t, calls)
+
+	count.SetValue(2)
+	count.SetValue(2)
+	count.SetValue(5)
+	assert.Equal(t, [][2]int{{2, 1}, {5, 2}}, calls)
+
+	w.Stop()
+	count.SetValue(6)
+	assert.Len(t, calls, 2)
+}
+
+func TestWatchComputedAndGetter(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	first := alien.Signal(rs, "Ada")
+	last := alien.Signal(rs, "Lovelace")
+	full := alien.Computed(rs, func(oldValue string) string {
+		return first.Value() + " " + last.Value()
+	})
+
+	names := []string{}
+	alien.Watch(rs, full, func(newValue, oldValue string) {
+		names = append(names, oldValue+" -> "+newValue)
+	})
+
+	initials := []string{}
+	alien.WatchFunc(rs, alien.Getter[[]string](func() []string {
+		return []string{first.Value()[:1], last.Value()[:1]}
+	}), func(newValue, oldValue []string) {
+		initials = append(initials, fmt.Sprint(oldValue, newValue))
+	}, alien.DeepEqual[[]string])
+
+	first.SetValue("Alan")
+	last.SetValue("Turing")
+	assert.Equal(t, []string{
+		"Ada Lovelace -> Alan Lovelace",
+		"Alan Lovelace -> Alan Turing",
+	}, names)
+	assert.Equal(t, []string{"[A L] [A T]"}, initials, "unchanged getter results are skipped")
+}
+
+func TestWatchImmediateAndOnce(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	immediate := [][2]int{}
+	alien.Watch(rs, count, func(newValue, oldValue int) {
+		immediate = append(immediate, [2]int{newValue, oldValue})
+	}, alien.WatchImmediate())
+	assert.Equal(t, [][2]int{{1, 0}}, immediate)
+
+	once := 0
+	alien.Watch(rs, count, func(newValue, oldValue int) {
+		once++
+	}, alien.WatchOnce())
+
+	onceImmediate := 0
+	alien.Watch(rs, count, func(newValue, oldValue int) {
+		onceImmediate++
+	}, alien.WatchOnce(), alien.WatchImmediate())
+	assert.Equal(t, 1, onceImmediate)
+
+	count.SetValue(2)
+	count.SetValue(3)
+	assert.Len(t, immediate, 3)
+	assert.Equal(t, 1, once)
+	assert.Equal(t, 1, onceImmediate)
+}
+
+func TestWatchFlushTiming(t *testing.T) {
+	inScope := func(rs *alien.ReactiveSystem, fn func()) {
+		alien.EffectScope(rs, func() error {
+			fn()
+			return nil
+		})
+	}
+	for name, tc := range map[string]struct {
+		opts  []alien.Option
+		setup func(rs *alien.ReactiveSystem, fn func())
+	}{
+		"top level": {setup: func(rs *alien.ReactiveSystem, fn func()) { fn() }},
+		"scope":     {setup: inScope},
+		"nested scope": {setup: func(rs *alien.ReactiveSystem, fn func()) {
+			inScope(rs, func() { inScope(rs, fn) })
+		}},
+		"scheduler": {
+			opts:  []alien.Option{alien.WithScheduler(alien.NewManualScheduler())},
+			setup: inScope,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+				assert.FailNow(t, err.Error())
+			}, tc.opts...)
+			count := alien.Signal(rs, 0)
+			logs := []string{}
+
+			tc.setup(rs, func() {
+				alien.Watch(rs, count, func(newValue, oldValue int) {
+					logs = append(logs, "post")
+				}, alien.WatchFlush(alien.FlushPost))
+				alien.Effect(rs, func() error {
+					count.Value()
+					logs = append(logs, "effect")
+					return nil
+				})
+				alien.Watch(rs, count, func(newValue, oldValue int) {
+					logs = append(logs, "pre")
+				})
+			})
+			logs = logs[:0]
+
+			count.SetValue(1)
+			rs.Flush()
+			assert.Equal(t, []string{"pre", "effect", "post"}, logs)
+		})
+	}
+}
+
+func TestWatchPauseAndResume(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 1)
+
+	calls := [][2]int{}
+	w := alien.Watch(rs, count, func(newValue, oldValue int) {
+		calls = append(calls, [2]int{newValue, oldValue})
+	})
+
+	w.Pause()
+	count.SetValue(2)
+	count.SetValue(3)
+	assert.Empty(t, calls)
+
+	w.Resume()
+	assert.Equal(t, [][2]int{{3, 1}}, calls)
+
+	w.Pause()
+	count.SetValue(4)
+	count.SetValue(3)
+	w.Resume()
+	assert.Len(t, calls, 1, "value is back to where it was before the pause")
+
+	count.SetValue(7)
+	assert.Equal(t, [][2]int{{3, 1}, {7, 3}}, calls)
+}
+
+func TestWatchCallbackIsNotTracked(t *testing.T) {
+	rs := alien.CreateReactiveSystem(func(from alien.SignalAware, err error) {
+		assert.FailNow(t, err.Error())
+	})
+	count := alien.Signal(rs, 0)
+	other := alien.Signal(rs, 0)
+
+	calls := 0
+	alien.Watch(rs, count, func(newValue, oldValue int) {
+		calls++
+		other.Value()
+	})
+
+	count.SetValue(1)
+	other.SetValue(1)
+	assert.Equal(t, 1, calls)
+}
@@ -73,6 +73,7 @@ func (rs *ReactiveSystem) evalAll() {
 type WriteableSignal[T comparable] struct {
 	rs    *ReactiveSystem
 	v     T
+	ver   uint32
 	state state
 }
 
@@ -88,9 +89,41 @@ func (s *WriteableSignal[T]) Value() T {
 func (s *WriteableSignal[T]) SetValue(value T) {
 	s.rs.mu.Lock()
 	defer s.rs.mu.Unlock()
+	s.set(value)
+}
+
+// Update replaces the value with fn applied to the current one. Read and
+// write happen under the same lock, so concurrent updates are never lost.
+func (s *WriteableSignal[T]) Update(fn func(old T) T) {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	s.set(fn(s.v))
+}
+
+// Version returns a number that changes every time the value is set.
+func (s *WriteableSignal[T]) Version() uint32 {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	return s.ver
+}
+
+// CompareAndSet sets value only if the signal is still at expectedVersion,
+// as returned by Version, and reports whether it did.
+func (s *WriteableSignal[T]) CompareAndSet(expectedVersion uint32, value T) bool {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	if s.ver != expectedVersion {
+		return false
+	}
+	s.set(value)
+	return true
+}
+
+func (s *WriteableSignal[T]) set(value T) {
 	s.state = dirty
 	s.rs.anyDirty = true
 	s.v = value
+	s.ver++
 	s.rs.evalAll()
 }
 
@@ -134,6 +167,8 @@ func (s *ReadonlySignal[O]) setDirty() {
 
 func (s *ReadonlySignal[O]) preEval() (o O, wasClean bool) {
 	if s.state == computing {
+		// cells only take cells that already exist as dependencies, so the
+		// graph can't loop back here short of a bug in eval
 		panic("circular dependency")
 	} else if s.state == clean {
 		return s.value, true
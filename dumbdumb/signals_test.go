@@ -3,6 +3,7 @@ package dumbdumb_test
 import (
 	"fmt"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -395,3 +396,45 @@ func TestShouldKeepGraphConsistentOnComputedErrors(t *testing.T) {
 	a.SetValue(1)
 	assert.Equal(t, 1, c.Value())
 }
+
+func TestUpdateIsAtomic(t *testing.T) {
+	rs := dumbdumb.NewReactiveSystem()
+	count := dumbdumb.Signal(rs, 0)
+	double := dumbdumb.Computed1(rs, count, func(c int) int {
+		return c * 2
+	})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				count.Update(func(old int) int {
+					return old + 1
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 800, count.Value())
+	assert.Equal(t, 1600, double.Value())
+}
+
+func TestCompareAndSet(t *testing.T) {
+	rs := dumbdumb.NewReactiveSystem()
+	count := dumbdumb.Signal(rs, 1)
+	double := dumbdumb.Computed1(rs, count, func(c int) int {
+		return c * 2
+	})
+
+	v := count.Version()
+	require.True(t, count.CompareAndSet(v, 2))
+	assert.Equal(t, 4, double.Value())
+	assert.NotEqual(t, v, count.Version())
+
+	assert.False(t, count.CompareAndSet(v, 3))
+	assert.Equal(t, 2, count.Value())
+	assert.Equal(t, 4, double.Value())
+}
@@ -1,8 +1,6 @@
 module github.com/delaneyj/signalparty
 
-go 1.22.0
-
-toolchain go1.24.0
+go 1.24.0
 
 require (
 	github.com/dustin/go-humanize v1.0.1
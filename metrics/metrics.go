@@ -0,0 +1,148 @@
+// Package metrics describes the work done by a reactive system and exports
+// it through expvar or in the Prometheus text exposition format.
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// Stats is a snapshot of the counters of a reactive system.
+type Stats struct {
+	// SignalWrites counts writes that changed a signal's value.
+	SignalWrites uint64
+	// ComputedRuns counts computed getter runs.
+	ComputedRuns uint64
+	// ComputedHits counts computed reads served from the cached value.
+	ComputedHits uint64
+	// EffectRuns counts effect runs, including the first one.
+	EffectRuns uint64
+	// PropagationDepth records how many levels of subscribers each write
+	// reached.
+	PropagationDepth Histogram
+	// Nodes is the number of live signals, computeds and effects.
+	Nodes int64
+	// Links is the number of dependency links between them.
+	Links int64
+}
+
+// DepthBuckets are the upper bounds used for PropagationDepth.
+var DepthBuckets = []uint64{1, 2, 4, 8, 16, 32, 64}
+
+// Histogram counts observations into buckets with inclusive upper bounds.
+// Counts has one more entry than Bounds for observations above the last
+// bound.
+type Histogram struct {
+	Bounds []uint64
+	Counts []uint64
+	Count  uint64
+	Sum    uint64
+}
+
+// NewHistogram creates an empty histogram with the given ascending bounds.
+func NewHistogram(bounds ...uint64) Histogram {
+	return Histogram{
+		Bounds: bounds,
+		Counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+// Observe adds v to the histogram.
+func (h *Histogram) Observe(v uint64) {
+	i, _ := slices.BinarySearch(h.Bounds, v)
+	h.Counts[i]++
+	h.Count++
+	h.Sum += v
+}
+
+// Clone returns a copy that doesn't share Counts with h.
+func (h Histogram) Clone() Histogram {
+	h.Counts = slices.Clone(h.Counts)
+	return h
+}
+
+// Source is anything that reports Stats, such as an alien or rocket
+// ReactiveSystem.
+type Source interface {
+	Stats() Stats
+}
+
+// Publish exports the stats of src as the expvar variable name. Like
+// expvar.Publish it panics if name is already in use.
+func Publish(name string, src Source) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return src.Stats()
+	}))
+}
+
+// Handler serves the stats of every system in the Prometheus text exposition
+// format, labelled with system="<key>".
+func Handler(systems map[string]Source) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WritePrometheus(w, systems)
+	})
+}
+
+// WritePrometheus writes the stats of every system in the Prometheus text
+// exposition format, systems sorted by name.
+func WritePrometheus(w io.Writer, systems map[string]Source) error {
+	names := make([]string, 0, len(systems))
+	for name := range systems {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	stats := make([]Stats, len(names))
+	for i, name := range names {
+		stats[i] = systems[name].Stats()
+	}
+
+	p := &promWriter{w: w}
+	scalar := func(metric, kind, help string, value func(s Stats) string) {
+		p.printf("# HELP %s %s\n# TYPE %s %s\n", metric, help, metric, kind)
+		for i, name := range names {
+			p.printf("%s{system=%q} %s\n", metric, name, value(stats[i]))
+		}
+	}
+	scalar("signalparty_signal_writes_total", "counter", "Writes that changed a signal's value.",
+		func(s Stats) string { return fmt.Sprint(s.SignalWrites) })
+	scalar("signalparty_computed_runs_total", "counter", "Computed getter runs.",
+		func(s Stats) string { return fmt.Sprint(s.ComputedRuns) })
+	scalar("signalparty_computed_hits_total", "counter", "Computed reads served from the cache.",
+		func(s Stats) string { return fmt.Sprint(s.ComputedHits) })
+	scalar("signalparty_effect_runs_total", "counter", "Effect runs.",
+		func(s Stats) string { return fmt.Sprint(s.EffectRuns) })
+	scalar("signalparty_nodes", "gauge", "Live signals, computeds and effects.",
+		func(s Stats) string { return fmt.Sprint(s.Nodes) })
+	scalar("signalparty_links", "gauge", "Dependency links between nodes.",
+		func(s Stats) string { return fmt.Sprint(s.Links) })
+
+	const depth = "signalparty_propagation_depth"
+	p.printf("# HELP %s Levels of subscribers reached by a write.\n# TYPE %s histogram\n", depth, depth)
+	for i, name := range names {
+		h := stats[i].PropagationDepth
+		var cumulative uint64
+		for j, bound := range h.Bounds {
+			cumulative += h.Counts[j]
+			p.printf("%s_bucket{system=%q,le=\"%d\"} %d\n", depth, name, bound, cumulative)
+		}
+		p.printf("%s_bucket{system=%q,le=\"+Inf\"} %d\n", depth, name, h.Count)
+		p.printf("%s_sum{system=%q} %d\n", depth, name, h.Sum)
+		p.printf("%s_count{system=%q} %d\n", depth, name, h.Count)
+	}
+	return p.err
+}
+
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *promWriter) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
@@ -0,0 +1,90 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/delaneyj/signalparty/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedSource metrics.Stats
+
+func (s fixedSource) Stats() metrics.Stats {
+	return metrics.Stats(s)
+}
+
+func sampleStats() metrics.Stats {
+	h := metrics.NewHistogram(1, 2, 4)
+	for _, v := range []uint64{1, 1, 3, 9} {
+		h.Observe(v)
+	}
+	return metrics.Stats{
+		SignalWrites:     4,
+		ComputedRuns:     3,
+		ComputedHits:     7,
+		EffectRuns:       2,
+		PropagationDepth: h,
+		Nodes:            5,
+		Links:            6,
+	}
+}
+
+func TestHistogramObserve(t *testing.T) {
+	h := metrics.NewHistogram(1, 2, 4)
+	for _, v := range []uint64{0, 1, 2, 3, 4, 5} {
+		h.Observe(v)
+	}
+	assert.Equal(t, []uint64{2, 1, 2, 1}, h.Counts)
+	assert.Equal(t, uint64(6), h.Count)
+	assert.Equal(t, uint64(15), h.Sum)
+
+	c := h.Clone()
+	h.Observe(1)
+	assert.Equal(t, []uint64{2, 1, 2, 1}, c.Counts)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	var sb strings.Builder
+	err := metrics.WritePrometheus(&sb, map[string]metrics.Source{
+		"ui": fixedSource(sampleStats()),
+	})
+	require.NoError(t, err)
+	out := sb.String()
+
+	assert.Contains(t, out, "# TYPE signalparty_signal_writes_total counter\n")
+	assert.Contains(t, out, "signalparty_signal_writes_total{system=\"ui\"} 4\n")
+	assert.Contains(t, out, "signalparty_computed_hits_total{system=\"ui\"} 7\n")
+	assert.Contains(t, out, "signalparty_links{system=\"ui\"} 6\n")
+	assert.Contains(t, out, "# TYPE signalparty_propagation_depth histogram\n")
+	assert.Contains(t, out, "signalparty_propagation_depth_bucket{system=\"ui\",le=\"1\"} 2\n")
+	assert.Contains(t, out, "signalparty_propagation_depth_bucket{system=\"ui\",le=\"4\"} 3\n")
+	assert.Contains(t, out, "signalparty_propagation_depth_bucket{system=\"ui\",le=\"+Inf\"} 4\n")
+	assert.Contains(t, out, "signalparty_propagation_depth_sum{system=\"ui\"} 14\n")
+}
+
+func TestHandler(t *testing.T) {
+	h := metrics.Handler(map[string]metrics.Source{
+		"b": fixedSource(sampleStats()),
+		"a": fixedSource{},
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	body := w.Body.String()
+	assert.Less(t, strings.Index(body, `system="a"`), strings.Index(body, `system="b"`))
+}
+
+func TestPublish(t *testing.T) {
+	metrics.Publish("signalparty_test", fixedSource(sampleStats()))
+
+	got := metrics.Stats{}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("signalparty_test").String()), &got))
+	assert.Equal(t, sampleStats(), got)
+}
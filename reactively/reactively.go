@@ -14,7 +14,9 @@ type ReactiveContext struct {
 	current         HasReactivity
 	currentGets     []any
 	currentGetIndex int
-	effectQueue     []any
+	effectQueue     []HasReactivity
+	stabilizing     bool
+	autoStabilize   bool
 }
 
 type reactiveOrCleanup interface {
@@ -123,6 +125,8 @@ func (r *Reactive[T]) Read() T {
 
 	if r.fn != nil {
 		r.updateIfNecessary()
+		// writes made by a memo computed outside of any reaction
+		r.rctx.stabilizeIfAuto()
 	}
 
 	return r.value
@@ -134,6 +138,7 @@ func (r *Reactive[T]) WriteFn(nextValueFn func() T) {
 	// so I'm just going to assume that it's always different and pay the cost
 	r.stale(CacheDirty) // TODO: is this correct? Naively I'd think it should be after assignment or both
 	r.fn = nextValueFn
+	r.rctx.stabilizeIfAuto()
 }
 
 func (r *Reactive[T]) Write(nextValue T) {
@@ -155,7 +160,7 @@ func (r *Reactive[T]) Write(nextValue T) {
 		}
 		r.value = nextValue
 	}
-
+	r.rctx.stabilizeIfAuto()
 }
 
 func (r *Reactive[T]) stale(state CacheState) {
@@ -164,6 +169,9 @@ func (r *Reactive[T]) stale(state CacheState) {
 	}
 	if r.state < state {
 		// If we were previously clean, then we know that we may need to update to get the new value
+		if r.state == CacheClean && r.isEffect {
+			r.rctx.effectQueue = append(r.rctx.effectQueue, r)
+		}
 		r.state = state
 		for _, observerRaw := range r.observers {
 			switch ob := observerRaw.(type) {
@@ -305,14 +313,37 @@ func OnCleanup[T comparable](rctx *ReactiveContext, fn CleanupFunc[T]) {
 	}
 }
 
-// run all non-clean effect nodes
-func Stablize[T comparable](rctx *ReactiveContext) {
-	for _, effect := range rctx.effectQueue {
-		switch effect := effect.(type) {
-		case *Reactive[T]:
-			effect.stale(CacheCheck)
-		default:
-			panic("not a reactive value")
-		}
+// Stabilize runs all non-clean effect nodes, including the ones that become
+// stale while it runs.
+func Stabilize(rctx *ReactiveContext) {
+	if rctx.stabilizing {
+		// the running Stabilize picks up anything queued in the meantime
+		return
+	}
+	rctx.stabilizing = true
+	defer func() {
+		rctx.stabilizing = false
+	}()
+
+	for len(rctx.effectQueue) > 0 {
+		effect := rctx.effectQueue[0]
+		rctx.effectQueue = rctx.effectQueue[1:]
+		effect.updateIfNecessary()
+	}
+}
+
+// AutoStabilize makes every Write, WriteFn and memo Read made outside of a
+// reactive function call Stabilize, so effects run without waiting for an
+// explicit Stabilize.
+func AutoStabilize(rctx *ReactiveContext, enabled bool) {
+	rctx.autoStabilize = enabled
+	if enabled {
+		rctx.stabilizeIfAuto()
+	}
+}
+
+func (rctx *ReactiveContext) stabilizeIfAuto() {
+	if rctx.autoStabilize && rctx.current == nil && len(rctx.effectQueue) > 0 {
+		Stabilize(rctx)
 	}
 }
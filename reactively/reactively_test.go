@@ -237,3 +237,114 @@ func TestCore(t *testing.T) {
 		assert.Equal(t, 102, l.Read())
 	})
 }
+
+func TestEffects(t *testing.T) {
+	t.Run("stabilize runs stale effects", func(t *testing.T) {
+		rctx := &ReactiveContext{}
+		a := Signal(rctx, 1)
+		b := Memo(rctx, func() int {
+			return a.Read() * 2
+		})
+
+		var seen []int
+		Effect(rctx, func() {
+			seen = append(seen, b.Read())
+		})
+		assert.Equal(t, []int{2}, seen)
+
+		a.Write(2)
+		a.Write(3)
+		assert.Equal(t, []int{2}, seen)
+
+		Stabilize(rctx)
+		assert.Equal(t, []int{2, 6}, seen)
+
+		Stabilize(rctx)
+		assert.Equal(t, []int{2, 6}, seen)
+	})
+
+	t.Run("unchanged memo skips effect", func(t *testing.T) {
+		rctx := &ReactiveContext{}
+		a := Signal(rctx, 1)
+		even := Memo(rctx, func() bool {
+			return a.Read()%2 == 0
+		})
+
+		runs := 0
+		Effect(rctx, func() {
+			even.Read()
+			runs++
+		})
+
+		a.Write(3)
+		Stabilize(rctx)
+		assert.Equal(t, 1, runs)
+
+		a.Write(4)
+		Stabilize(rctx)
+		assert.Equal(t, 2, runs)
+	})
+
+	t.Run("effects of different types", func(t *testing.T) {
+		rctx := &ReactiveContext{}
+		n := Signal(rctx, 1)
+		s := Signal(rctx, "a")
+
+		var gotN int
+		var gotS string
+		Effect(rctx, func() {
+			gotN = n.Read()
+		})
+		Effect(rctx, func() {
+			gotS = s.Read()
+		})
+
+		n.Write(2)
+		s.Write("b")
+		Stabilize(rctx)
+		assert.Equal(t, 2, gotN)
+		assert.Equal(t, "b", gotS)
+	})
+
+	t.Run("effect writing a signal", func(t *testing.T) {
+		rctx := &ReactiveContext{}
+		a := Signal(rctx, 1)
+		b := Signal(rctx, 0)
+
+		Effect(rctx, func() {
+			b.Write(a.Read() * 10)
+		})
+		var got int
+		Effect(rctx, func() {
+			got = b.Read()
+		})
+		assert.Equal(t, 10, got)
+
+		a.Write(2)
+		Stabilize(rctx)
+		assert.Equal(t, 20, got)
+	})
+
+	t.Run("auto stabilize", func(t *testing.T) {
+		rctx := &ReactiveContext{}
+		a := Signal(rctx, 1)
+
+		var got int
+		Effect(rctx, func() {
+			got = a.Read()
+		})
+
+		a.Write(2)
+		assert.Equal(t, 1, got)
+
+		AutoStabilize(rctx, true)
+		assert.Equal(t, 2, got)
+
+		a.Write(3)
+		assert.Equal(t, 3, got)
+
+		AutoStabilize(rctx, false)
+		a.Write(4)
+		assert.Equal(t, 3, got)
+	})
+}
@@ -1,16 +1,91 @@
 package rocket
 
-import "sync"
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/delaneyj/signalparty/metrics"
+)
 
 type ReactiveSystem struct {
-	mu       sync.Mutex
-	inEffect bool
+	mu              reentrantMutex
+	stats           metrics.Stats
+	depth, maxDepth uint64
 }
 
 func NewReactiveSystem() *ReactiveSystem {
 	return &ReactiveSystem{
-		mu: sync.Mutex{},
+		stats: metrics.Stats{
+			PropagationDepth: metrics.NewHistogram(metrics.DepthBuckets...),
+		},
+	}
+}
+
+// reentrantMutex is a mutex the owning goroutine can lock again, so effects
+// running under the system lock can read and write signals.
+type reentrantMutex struct {
+	mu    sync.Mutex
+	owner atomic.Int64
+	depth int
+}
+
+func (m *reentrantMutex) Lock() {
+	id := goroutineID()
+	if m.owner.Load() == id {
+		m.depth++
+		return
+	}
+	m.mu.Lock()
+	m.owner.Store(id)
+	m.depth = 1
+}
+
+func (m *reentrantMutex) Unlock() {
+	m.depth--
+	if m.depth == 0 {
+		m.owner.Store(0)
+		m.mu.Unlock()
+	}
+}
+
+// goroutineID parses the id out of the "goroutine 123 [running]:" header
+// that runtime.Stack writes for the calling goroutine.
+func goroutineID() int64 {
+	var buf [32]byte
+	n := runtime.Stack(buf[:], false)
+	const prefix = len("goroutine ")
+
+	var id int64
+	for i := prefix; i < n; i++ {
+		c := buf[i]
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + int64(c-'0')
 	}
+	return id
+}
+
+// Stats returns a snapshot of the work done by the system.
+func (rs *ReactiveSystem) Stats() metrics.Stats {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	s := rs.stats
+	s.PropagationDepth = s.PropagationDepth.Clone()
+	return s
+}
+
+// enterLevel and leaveLevel track how deep a write propagates.
+func (rs *ReactiveSystem) enterLevel() {
+	rs.depth++
+	if rs.depth > rs.maxDepth {
+		rs.maxDepth = rs.depth
+	}
+}
+
+func (rs *ReactiveSystem) leaveLevel() {
+	rs.depth--
 }
 
 type Subscriber interface {
@@ -32,10 +107,8 @@ type WriteableSignal[T comparable] struct {
 }
 
 func (s *WriteableSignal[T]) Value() T {
-	if !s.rs.inEffect {
-		s.rs.mu.Lock()
-		defer s.rs.mu.Unlock()
-	}
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
 	return s.val
 }
 
@@ -48,25 +121,62 @@ func (s *WriteableSignal[T]) version() uint32 {
 }
 
 func (s *WriteableSignal[T]) SetValue(value T) {
-	if !s.rs.inEffect {
-		s.rs.mu.Lock()
-		defer s.rs.mu.Unlock()
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	s.set(value)
+}
+
+// Update replaces the value with fn applied to the current one. Read and
+// write happen under the same lock, so concurrent updates are never lost.
+func (s *WriteableSignal[T]) Update(fn func(old T) T) {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	s.set(fn(s.val))
+}
+
+// Version returns a number that changes every time the value does.
+func (s *WriteableSignal[T]) Version() uint32 {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	return s.ver
+}
+
+// CompareAndSet writes value only if the signal is still at expectedVersion,
+// as returned by Version, and reports whether it did.
+func (s *WriteableSignal[T]) CompareAndSet(expectedVersion uint32, value T) bool {
+	s.rs.mu.Lock()
+	defer s.rs.mu.Unlock()
+	if s.ver != expectedVersion {
+		return false
 	}
+	s.set(value)
+	return true
+}
 
+func (s *WriteableSignal[T]) set(value T) {
 	if s.val == value {
 		return
 	}
 	s.val = value
+	s.ver++
+	s.rs.stats.SignalWrites++
 
 	if len(s.subs) == 0 {
 		return
 	}
-	s.ver++
+
+	depth, maxDepth := s.rs.depth, s.rs.maxDepth
+	s.rs.depth, s.rs.maxDepth = 0, 0
 	s.markDirty()
+	s.rs.stats.PropagationDepth.Observe(s.rs.maxDepth)
+	s.rs.depth, s.rs.maxDepth = depth, maxDepth
 }
 
 func Signal[T comparable](rs *ReactiveSystem, value T) *WriteableSignal[T] {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 	s := &WriteableSignal[T]{rs: rs, val: value, ver: 1}
+	rs.stats.Nodes++
 	return s
 }
 
@@ -78,12 +188,14 @@ func (s *WriteableSignal[T]) markDirty() {
 
 func (s *WriteableSignal[T]) addSubs(sub ...Subscriber) {
 	s.subs = append(s.subs, sub...)
+	s.rs.stats.Links += int64(len(sub))
 }
 
 func (s *WriteableSignal[T]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -115,6 +227,7 @@ func Computed1[T0, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal1[T0, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -122,6 +235,7 @@ func Computed1[T0, O comparable](
 		dep0:       dep0,
 	}
 	dep0.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -134,6 +248,7 @@ func (s *ReadonlySignal1[T0, O]) Value() O {
 
 func (s *ReadonlySignal1[T0, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -147,10 +262,12 @@ func (s *ReadonlySignal1[T0, O]) value() any {
 	depVersion0 := s.dep0.version()
 	allArgsSum += depVersion0
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 	)
@@ -168,19 +285,23 @@ func (s *ReadonlySignal1[T0, O]) version() uint32 {
 
 func (s *ReadonlySignal1[T0, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal1[T0, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal1[T0, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -208,17 +329,18 @@ func Effect1[T0 comparable](
 		dep0:       dep0,
 	}
 	dep0.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
 		defer rs.mu.Unlock()
 		dep0.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -237,17 +359,18 @@ func (s *SideEffect1[T0]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect1[T0]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal2Func[T0, T1, O comparable] func(T0, T1) O
@@ -279,6 +402,7 @@ func Computed2[T0, T1, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal2[T0, T1, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -288,6 +412,7 @@ func Computed2[T0, T1, O comparable](
 	}
 	dep0.addSubs(s)
 	dep1.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -300,6 +425,7 @@ func (s *ReadonlySignal2[T0, T1, O]) Value() O {
 
 func (s *ReadonlySignal2[T0, T1, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -320,10 +446,12 @@ func (s *ReadonlySignal2[T0, T1, O]) value() any {
 	depVersion1 := s.dep1.version()
 	allArgsSum += depVersion1
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -342,19 +470,23 @@ func (s *ReadonlySignal2[T0, T1, O]) version() uint32 {
 
 func (s *ReadonlySignal2[T0, T1, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal2[T0, T1, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal2[T0, T1, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -386,19 +518,20 @@ func Effect2[T0, T1 comparable](
 	}
 	dep0.addSubs(s)
 	dep1.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
 		defer rs.mu.Unlock()
 		dep0.removeSub(s)
 		dep1.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -425,18 +558,19 @@ func (s *SideEffect2[T0, T1]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect2[T0, T1]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal3Func[T0, T1, T2, O comparable] func(T0, T1, T2) O
@@ -471,6 +605,7 @@ func Computed3[T0, T1, T2, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal3[T0, T1, T2, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -482,6 +617,7 @@ func Computed3[T0, T1, T2, O comparable](
 	dep0.addSubs(s)
 	dep1.addSubs(s)
 	dep2.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -494,6 +630,7 @@ func (s *ReadonlySignal3[T0, T1, T2, O]) Value() O {
 
 func (s *ReadonlySignal3[T0, T1, T2, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -521,10 +658,12 @@ func (s *ReadonlySignal3[T0, T1, T2, O]) value() any {
 	depVersion2 := s.dep2.version()
 	allArgsSum += depVersion2
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -544,19 +683,23 @@ func (s *ReadonlySignal3[T0, T1, T2, O]) version() uint32 {
 
 func (s *ReadonlySignal3[T0, T1, T2, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal3[T0, T1, T2, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal3[T0, T1, T2, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -592,14 +735,14 @@ func Effect3[T0, T1, T2 comparable](
 	dep0.addSubs(s)
 	dep1.addSubs(s)
 	dep2.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
 		dep2.value().(T2),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
@@ -607,6 +750,7 @@ func Effect3[T0, T1, T2 comparable](
 		dep0.removeSub(s)
 		dep1.removeSub(s)
 		dep2.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -641,19 +785,20 @@ func (s *SideEffect3[T0, T1, T2]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
 		current2,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect3[T0, T1, T2]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal4Func[T0, T1, T2, T3, O comparable] func(T0, T1, T2, T3) O
@@ -691,6 +836,7 @@ func Computed4[T0, T1, T2, T3, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal4[T0, T1, T2, T3, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -704,6 +850,7 @@ func Computed4[T0, T1, T2, T3, O comparable](
 	dep1.addSubs(s)
 	dep2.addSubs(s)
 	dep3.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -716,6 +863,7 @@ func (s *ReadonlySignal4[T0, T1, T2, T3, O]) Value() O {
 
 func (s *ReadonlySignal4[T0, T1, T2, T3, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -750,10 +898,12 @@ func (s *ReadonlySignal4[T0, T1, T2, T3, O]) value() any {
 	depVersion3 := s.dep3.version()
 	allArgsSum += depVersion3
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -774,19 +924,23 @@ func (s *ReadonlySignal4[T0, T1, T2, T3, O]) version() uint32 {
 
 func (s *ReadonlySignal4[T0, T1, T2, T3, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal4[T0, T1, T2, T3, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal4[T0, T1, T2, T3, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -826,15 +980,15 @@ func Effect4[T0, T1, T2, T3 comparable](
 	dep1.addSubs(s)
 	dep2.addSubs(s)
 	dep3.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
 		dep2.value().(T2),
 		dep3.value().(T3),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
@@ -843,6 +997,7 @@ func Effect4[T0, T1, T2, T3 comparable](
 		dep1.removeSub(s)
 		dep2.removeSub(s)
 		dep3.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -885,20 +1040,21 @@ func (s *SideEffect4[T0, T1, T2, T3]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
 		current2,
 		current3,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect4[T0, T1, T2, T3]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal5Func[T0, T1, T2, T3, T4, O comparable] func(T0, T1, T2, T3, T4) O
@@ -939,6 +1095,7 @@ func Computed5[T0, T1, T2, T3, T4, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal5[T0, T1, T2, T3, T4, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -954,6 +1111,7 @@ func Computed5[T0, T1, T2, T3, T4, O comparable](
 	dep2.addSubs(s)
 	dep3.addSubs(s)
 	dep4.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -966,6 +1124,7 @@ func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) Value() O {
 
 func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -1007,10 +1166,12 @@ func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) value() any {
 	depVersion4 := s.dep4.version()
 	allArgsSum += depVersion4
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -1032,19 +1193,23 @@ func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) version() uint32 {
 
 func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal5[T0, T1, T2, T3, T4, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -1088,8 +1253,9 @@ func Effect5[T0, T1, T2, T3, T4 comparable](
 	dep2.addSubs(s)
 	dep3.addSubs(s)
 	dep4.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
@@ -1097,7 +1263,6 @@ func Effect5[T0, T1, T2, T3, T4 comparable](
 		dep3.value().(T3),
 		dep4.value().(T4),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
@@ -1107,6 +1272,7 @@ func Effect5[T0, T1, T2, T3, T4 comparable](
 		dep2.removeSub(s)
 		dep3.removeSub(s)
 		dep4.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -1157,8 +1323,8 @@ func (s *SideEffect5[T0, T1, T2, T3, T4]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
@@ -1166,12 +1332,13 @@ func (s *SideEffect5[T0, T1, T2, T3, T4]) value() any {
 		current3,
 		current4,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect5[T0, T1, T2, T3, T4]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal6Func[T0, T1, T2, T3, T4, T5, O comparable] func(T0, T1, T2, T3, T4, T5) O
@@ -1215,6 +1382,7 @@ func Computed6[T0, T1, T2, T3, T4, T5, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -1232,6 +1400,7 @@ func Computed6[T0, T1, T2, T3, T4, T5, O comparable](
 	dep3.addSubs(s)
 	dep4.addSubs(s)
 	dep5.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -1244,6 +1413,7 @@ func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) Value() O {
 
 func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -1292,10 +1462,12 @@ func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) value() any {
 	depVersion5 := s.dep5.version()
 	allArgsSum += depVersion5
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -1318,19 +1490,23 @@ func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) version() uint32 {
 
 func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal6[T0, T1, T2, T3, T4, T5, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -1378,8 +1554,9 @@ func Effect6[T0, T1, T2, T3, T4, T5 comparable](
 	dep3.addSubs(s)
 	dep4.addSubs(s)
 	dep5.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
@@ -1388,7 +1565,6 @@ func Effect6[T0, T1, T2, T3, T4, T5 comparable](
 		dep4.value().(T4),
 		dep5.value().(T5),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
@@ -1399,6 +1575,7 @@ func Effect6[T0, T1, T2, T3, T4, T5 comparable](
 		dep3.removeSub(s)
 		dep4.removeSub(s)
 		dep5.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -1457,8 +1634,8 @@ func (s *SideEffect6[T0, T1, T2, T3, T4, T5]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
@@ -1467,12 +1644,13 @@ func (s *SideEffect6[T0, T1, T2, T3, T4, T5]) value() any {
 		current4,
 		current5,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect6[T0, T1, T2, T3, T4, T5]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal7Func[T0, T1, T2, T3, T4, T5, T6, O comparable] func(T0, T1, T2, T3, T4, T5, T6) O
@@ -1519,6 +1697,7 @@ func Computed7[T0, T1, T2, T3, T4, T5, T6, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -1538,6 +1717,7 @@ func Computed7[T0, T1, T2, T3, T4, T5, T6, O comparable](
 	dep4.addSubs(s)
 	dep5.addSubs(s)
 	dep6.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -1550,6 +1730,7 @@ func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) Value() O {
 
 func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -1605,10 +1786,12 @@ func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) value() any {
 	depVersion6 := s.dep6.version()
 	allArgsSum += depVersion6
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -1632,19 +1815,23 @@ func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) version() uint32 {
 
 func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal7[T0, T1, T2, T3, T4, T5, T6, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -1696,8 +1883,9 @@ func Effect7[T0, T1, T2, T3, T4, T5, T6 comparable](
 	dep4.addSubs(s)
 	dep5.addSubs(s)
 	dep6.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
@@ -1707,7 +1895,6 @@ func Effect7[T0, T1, T2, T3, T4, T5, T6 comparable](
 		dep5.value().(T5),
 		dep6.value().(T6),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
@@ -1719,6 +1906,7 @@ func Effect7[T0, T1, T2, T3, T4, T5, T6 comparable](
 		dep4.removeSub(s)
 		dep5.removeSub(s)
 		dep6.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -1785,8 +1973,8 @@ func (s *SideEffect7[T0, T1, T2, T3, T4, T5, T6]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
@@ -1796,12 +1984,13 @@ func (s *SideEffect7[T0, T1, T2, T3, T4, T5, T6]) value() any {
 		current5,
 		current6,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect7[T0, T1, T2, T3, T4, T5, T6]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
 
 type ReadonlySignal8Func[T0, T1, T2, T3, T4, T5, T6, T7, O comparable] func(T0, T1, T2, T3, T4, T5, T6, T7) O
@@ -1851,6 +2040,7 @@ func Computed8[T0, T1, T2, T3, T4, T5, T6, T7, O comparable](
 	defer rs.mu.Unlock()
 
 	s := &ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]{
+		rs:         rs,
 		isDirty:    true,
 		get:        get,
 		ver:        1,
@@ -1872,6 +2062,7 @@ func Computed8[T0, T1, T2, T3, T4, T5, T6, T7, O comparable](
 	dep5.addSubs(s)
 	dep6.addSubs(s)
 	dep7.addSubs(s)
+	rs.stats.Nodes++
 
 	return s
 }
@@ -1884,6 +2075,7 @@ func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) Value() O {
 
 func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) value() any {
 	if !s.isDirty {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 	s.isDirty = false
@@ -1946,10 +2138,12 @@ func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) value() any {
 	depVersion7 := s.dep7.version()
 	allArgsSum += depVersion7
 	if allArgsSum == s.versionSum {
+		s.rs.stats.ComputedHits++
 		return s.val
 	}
 
 	s.versionSum = allArgsSum
+	s.rs.stats.ComputedRuns++
 	currentValue := s.get(
 		depValue0,
 		depValue1,
@@ -1974,19 +2168,23 @@ func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) version() uint32 {
 
 func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) markDirty() {
 	s.isDirty = true
+	s.rs.enterLevel()
 	for _, sub := range s.subs {
 		sub.markDirty()
 	}
+	s.rs.leaveLevel()
 }
 
 func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) addSubs(subs ...Subscriber) {
 	s.subs = append(s.subs, subs...)
+	s.rs.stats.Links += int64(len(subs))
 }
 
 func (s *ReadonlySignal8[T0, T1, T2, T3, T4, T5, T6, T7, O]) removeSub(toRemove Subscriber) {
 	for i, sub := range s.subs {
 		if sub == toRemove {
 			s.subs = append(s.subs[:i], s.subs[i+1:]...)
+			s.rs.stats.Links--
 			return
 		}
 	}
@@ -2042,8 +2240,9 @@ func Effect8[T0, T1, T2, T3, T4, T5, T6, T7 comparable](
 	dep5.addSubs(s)
 	dep6.addSubs(s)
 	dep7.addSubs(s)
+	rs.stats.Nodes++
+	rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		dep0.value().(T0),
 		dep1.value().(T1),
@@ -2054,7 +2253,6 @@ func Effect8[T0, T1, T2, T3, T4, T5, T6, T7 comparable](
 		dep6.value().(T6),
 		dep7.value().(T7),
 	)
-	s.rs.inEffect = false
 
 	return func() {
 		rs.mu.Lock()
@@ -2067,6 +2265,7 @@ func Effect8[T0, T1, T2, T3, T4, T5, T6, T7 comparable](
 		dep5.removeSub(s)
 		dep6.removeSub(s)
 		dep7.removeSub(s)
+		rs.stats.Nodes--
 	}
 }
 
@@ -2141,8 +2340,8 @@ func (s *SideEffect8[T0, T1, T2, T3, T4, T5, T6, T7]) value() any {
 		return nil
 	}
 	s.versionSum = allArgsSum
+	s.rs.stats.EffectRuns++
 
-	s.rs.inEffect = true
 	s.fn(
 		current0,
 		current1,
@@ -2153,10 +2352,11 @@ func (s *SideEffect8[T0, T1, T2, T3, T4, T5, T6, T7]) value() any {
 		current6,
 		current7,
 	)
-	s.rs.inEffect = false
 	return nil
 }
 
 func (s *SideEffect8[T0, T1, T2, T3, T4, T5, T6, T7]) markDirty() {
+	s.rs.enterLevel()
 	s.value()
+	s.rs.leaveLevel()
 }
@@ -2,6 +2,7 @@ package rocket_test
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -398,3 +399,87 @@ func TestShouldKeepGraphConsistentOnComputedErrors(t *testing.T) {
 	a.SetValue(1)
 	assert.Equal(t, 1, c.Value())
 }
+
+func TestStats(t *testing.T) {
+	rs := rocket.NewReactiveSystem()
+	count := rocket.Signal(rs, 1)
+	double := rocket.Computed1(rs, count, doubleCount[int])
+
+	runs := 0
+	stop := rocket.Effect1(rs, double, func(v int) error {
+		runs++
+		return nil
+	})
+
+	stats := rs.Stats()
+	assert.Equal(t, int64(3), stats.Nodes)
+	assert.Equal(t, int64(2), stats.Links)
+	assert.Equal(t, uint64(1), stats.EffectRuns)
+	assert.Equal(t, uint64(1), stats.ComputedRuns)
+
+	double.Value()
+	count.SetValue(2)
+	count.SetValue(2)
+
+	stats = rs.Stats()
+	assert.Equal(t, uint64(1), stats.SignalWrites)
+	assert.Equal(t, uint64(2), stats.ComputedRuns)
+	assert.Equal(t, uint64(1), stats.ComputedHits)
+	assert.Equal(t, uint64(2), stats.EffectRuns)
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, uint64(1), stats.PropagationDepth.Count)
+	assert.Equal(t, uint64(2), stats.PropagationDepth.Sum)
+
+	stop()
+	stats = rs.Stats()
+	assert.Equal(t, int64(2), stats.Nodes)
+	assert.Equal(t, int64(1), stats.Links)
+}
+
+func TestUpdateIsAtomic(t *testing.T) {
+	rs := rocket.NewReactiveSystem()
+	count := rocket.Signal(rs, 0)
+	double := rocket.Computed1(rs, count, doubleCount[int])
+	mirror := rocket.Signal(rs, 0)
+	runs := 0
+	rocket.Effect1(rs, count, func(c int) error {
+		runs++
+		// effects run under the system lock and may write other signals
+		mirror.SetValue(c)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				count.Update(func(old int) int {
+					return old + 1
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 800, count.Value())
+	assert.Equal(t, 1600, double.Value())
+	assert.Equal(t, 801, runs)
+	assert.Equal(t, 800, mirror.Value())
+}
+
+func TestCompareAndSet(t *testing.T) {
+	rs := rocket.NewReactiveSystem()
+	count := rocket.Signal(rs, 1)
+	double := rocket.Computed1(rs, count, doubleCount[int])
+
+	v := count.Version()
+	require.True(t, count.CompareAndSet(v, 2))
+	assert.Equal(t, 4, double.Value())
+	assert.NotEqual(t, v, count.Version())
+
+	assert.False(t, count.CompareAndSet(v, 3))
+	assert.Equal(t, 2, count.Value())
+	assert.Equal(t, 4, double.Value())
+}